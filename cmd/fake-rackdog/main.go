@@ -0,0 +1,34 @@
+// Command fake-rackdog serves the in-memory fake Rackdog API so the
+// provider can be run against it locally:
+//
+//	go run ./cmd/fake-rackdog -addr 127.0.0.1:8080 -key test
+//	export RACKDOG_ENDPOINT=http://127.0.0.1:8080 RACKDOG_API_KEY=test
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	key := flag.String("key", "", "API key to require in x-rd-key (empty accepts any request)")
+	delay := flag.Duration("provision-delay", 5*time.Second, "How long servers stay in transitional states")
+	flag.Parse()
+
+	api := fakeapi.New(fakeapi.Options{APIKey: *key, ProvisionDelay: *delay})
+	log.Printf("fake Rackdog API listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, logRequests(api)))
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Printf("%s %s (%s)", r.Method, r.URL.RequestURI(), time.Since(start).Round(time.Microsecond))
+	})
+}
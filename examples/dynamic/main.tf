@@ -7,19 +7,20 @@ provider "rackdog" {
   recreate_on_missing = true
 }
 
-data "rackdog_operating_systems" "all" {}
-
-data "rackdog_plans" "ny" { location = "ny" }
+data "rackdog_operating_system" "ubuntu" {
+  family = "Ubuntu"
+  latest = true
+}
 
-locals {
-  chosen_plan = one([for p in data.rackdog_plans.ny.plans : p if p.name == "test"])
-  chosen_os   = data.rackdog_operating_systems.all.operating_systems[0]
+data "rackdog_plan" "test" {
+  name     = "test"
+  location = "ny"
 }
 
 resource "rackdog_server" "web" {
-  plan_id     = local.chosen_plan.id
+  plan_id     = data.rackdog_plan.test.id
   location_id = 1
-  os_id       = local.chosen_os.id                    
+  os_id       = data.rackdog_operating_system.ubuntu.id
   hostname    = "web-01"
   # raid     = 1
 }
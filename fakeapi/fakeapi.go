@@ -0,0 +1,354 @@
+// Package fakeapi is an in-memory, stateful stand-in for the Rackdog API.
+//
+// It serves the same paths and envelopes as the real API so the provider
+// can be exercised end to end without network access. Servers go through
+// asynchronous states: an allocated server reports PROVISIONING until
+// ProvisionDelay has passed and ON afterwards.
+//
+//	api := fakeapi.New(fakeapi.Options{APIKey: "test"})
+//	srv := httptest.NewServer(api)
+//	defer srv.Close()
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Power statuses reported in devicePowerStatus.
+const (
+	StatusProvisioning = "PROVISIONING"
+	StatusReinstalling = "REINSTALLING"
+	StatusOn           = "ON"
+	StatusOff          = "OFF"
+	StatusFailed       = "FAILED"
+)
+
+// Options configures a fake API. The zero value serves DefaultCatalog with
+// no authentication and instant provisioning.
+type Options struct {
+	// APIKey, if set, is required in the x-rd-key header of every request.
+	APIKey string
+	// ProvisionDelay is how long allocate, reinstall and power actions stay
+	// in their transitional status.
+	ProvisionDelay time.Duration
+	// ActionDelay is how long reinstalls and power actions stay queued
+	// after the API accepted them. Until then the server keeps reporting
+	// its previous status, as the real API does.
+	ActionDelay time.Duration
+	// Catalog replaces DefaultCatalog.
+	Catalog *Catalog
+	// MaxPageSize caps the limit parameter of GET /v1/servers. Zero means
+	// 100.
+	MaxPageSize int
+}
+
+const defaultMaxPageSize = 100
+
+// maxUserDataSize is the largest decoded userData allocate and reinstall
+// accept.
+const maxUserDataSize = 64 << 10
+
+// Tag limits.
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// Catalog is the orderable inventory served under /v1/ordering.
+type Catalog struct {
+	Plans            []Plan
+	OperatingSystems []OS
+	Locations        []Location
+}
+
+type CPU struct {
+	Name  string  `json:"name"`
+	Cores int     `json:"cores"`
+	Speed float64 `json:"speedGhz"`
+}
+
+type PlanLocation struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Keyword      string  `json:"keyword"`
+	MonthlyPrice float64 `json:"monthlyPrice"`
+}
+
+type Plan struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	CPU       CPU            `json:"cpu"`
+	Locations []PlanLocation `json:"locations"`
+	RAMGB     int            `json:"ram"`
+	Storage   int            `json:"storageGb"`
+	CreatedAt string         `json:"createdAt,omitempty"`
+	// RaidLevels lists the RAID levels the raid check accepts.
+	RaidLevels []int `json:"-"`
+}
+
+type OS struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Location struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Keyword string `json:"keyword"`
+	Country string `json:"country"`
+}
+
+// DefaultCatalog returns a small catalog: two locations, three plans and
+// three operating systems.
+func DefaultCatalog() *Catalog {
+	return &Catalog{
+		Locations: []Location{
+			{ID: 1, Name: "New York", Keyword: "ny", Country: "US"},
+			{ID: 2, Name: "Los Angeles", Keyword: "la", Country: "US"},
+		},
+		Plans: []Plan{
+			{
+				ID: 10, Name: "c1.small", RAMGB: 32, Storage: 480,
+				CPU:        CPU{Name: "Intel Xeon E-2236", Cores: 6, Speed: 3.4},
+				Locations:  []PlanLocation{{ID: 1, Name: "New York", Keyword: "ny", MonthlyPrice: 79}, {ID: 2, Name: "Los Angeles", Keyword: "la", MonthlyPrice: 69}},
+				CreatedAt:  "2024-01-10T00:00:00Z",
+				RaidLevels: []int{0, 1},
+			},
+			{
+				ID: 11, Name: "c1.medium", RAMGB: 64, Storage: 960,
+				CPU:        CPU{Name: "AMD EPYC 4244P", Cores: 6, Speed: 3.8},
+				Locations:  []PlanLocation{{ID: 1, Name: "New York", Keyword: "ny", MonthlyPrice: 149}},
+				CreatedAt:  "2024-06-01T00:00:00Z",
+				RaidLevels: []int{0, 1},
+			},
+			{
+				ID: 12, Name: "c1.large", RAMGB: 128, Storage: 3840,
+				CPU:        CPU{Name: "AMD EPYC 4464P", Cores: 12, Speed: 3.7},
+				Locations:  []PlanLocation{{ID: 1, Name: "New York", Keyword: "ny", MonthlyPrice: 229}, {ID: 2, Name: "Los Angeles", Keyword: "la", MonthlyPrice: 219}},
+				CreatedAt:  "2025-02-15T00:00:00Z",
+				RaidLevels: []int{0, 1, 10},
+			},
+		},
+		OperatingSystems: []OS{
+			{ID: 60, Name: "Ubuntu 22.04"},
+			{ID: 62, Name: "Ubuntu 24.04"},
+			{ID: 70, Name: "Debian 12"},
+		},
+	}
+}
+
+// ServerRecord is the fake's view of one allocated server.
+type ServerRecord struct {
+	ID         string
+	PlanID     int
+	LocationID int
+	OSID       int
+	Raid       *int
+	Hostname   string
+	SSHKeyIDs  []string
+	// UserData is the decoded user data sent with the last allocate or
+	// reinstall.
+	UserData  []byte
+	Tags      map[string]string
+	IPAddress string
+	// PTR is the reverse DNS name of IPAddress; empty means the default.
+	PTR         string
+	PowerStatus string
+	CreatedAt   time.Time
+	// Reboots counts the reboots the API accepted.
+	Reboots int
+
+	// pending is the transitional status a queued action enters at
+	// startAt; next is the status the server moves to at readyAt.
+	pending string
+	startAt time.Time
+	next    string
+	readyAt time.Time
+}
+
+// API is the fake Rackdog API. It implements http.Handler and is safe for
+// concurrent use.
+type API struct {
+	opts    Options
+	catalog *Catalog
+	mux     *http.ServeMux
+
+	mu      sync.Mutex
+	servers map[string]*ServerRecord
+	nextID  int
+	// orders maps Idempotency-Key headers to the response of the order
+	// they placed.
+	orders map[string]map[string]any
+	// dropAllocates is how many allocate responses to drop.
+	dropAllocates int
+	sshKeys       map[string]*SSHKeyRecord
+	nextKeyID     int
+	// Now is the clock used for provisioning transitions. Tests can replace
+	// it before serving requests.
+	Now func() time.Time
+}
+
+// New returns a fake API with no servers.
+func New(opts Options) *API {
+	a := &API{
+		opts:    opts,
+		catalog: opts.Catalog,
+		servers: map[string]*ServerRecord{},
+		orders:  map[string]map[string]any{},
+		sshKeys: map[string]*SSHKeyRecord{},
+		Now:     time.Now,
+	}
+	if a.catalog == nil {
+		a.catalog = DefaultCatalog()
+	}
+	a.routes()
+	return a
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a.opts.APIKey != "" && r.Header.Get("x-rd-key") != a.opts.APIKey {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Invalid API key")
+		return
+	}
+	a.mux.ServeHTTP(w, r)
+}
+
+// Server returns a copy of the server with the given ID, after applying any
+// due status transition.
+func (a *API) Server(id string) (ServerRecord, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.servers[id]
+	if !ok {
+		return ServerRecord{}, false
+	}
+	a.settle(s)
+	return *s, true
+}
+
+// Settled reports whether the server with the given ID exists and has no
+// queued or transitional status left.
+func (a *API) Settled(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.servers[id]
+	if !ok {
+		return false
+	}
+	a.settle(s)
+	return s.pending == "" && s.next == ""
+}
+
+// Servers returns copies of all servers, ordered by ID.
+func (a *API) Servers() []ServerRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]ServerRecord, 0, len(a.servers))
+	for _, s := range a.servers {
+		a.settle(s)
+		out = append(out, *s)
+	}
+	slices.SortFunc(out, func(x, y ServerRecord) int { return strings.Compare(x.ID, y.ID) })
+	return out
+}
+
+// Mutate applies fn to a server, simulating an out-of-band change made in
+// the Rackdog portal. It reports whether the server exists.
+func (a *API) Mutate(id string, fn func(*ServerRecord)) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.servers[id]
+	if !ok {
+		return false
+	}
+	a.settle(s)
+	fn(s)
+	return true
+}
+
+// Remove deletes a server as if it were destroyed out of band.
+func (a *API) Remove(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.servers[id]
+	delete(a.servers, id)
+	return ok
+}
+
+// DropAllocateResponses makes the next n allocate requests succeed but
+// close the connection instead of responding, like a network failure after
+// the order went through.
+func (a *API) DropAllocateResponses(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.dropAllocates = n
+}
+
+// settle moves s through its queued statuses once they are due. Callers
+// hold a.mu.
+func (a *API) settle(s *ServerRecord) {
+	now := a.Now()
+	if s.pending != "" && !now.Before(s.startAt) {
+		s.PowerStatus, s.pending = s.pending, ""
+	}
+	if s.pending == "" && s.next != "" && !now.Before(s.readyAt) {
+		s.PowerStatus, s.next = s.next, ""
+	}
+}
+
+// transition puts s into status now and moves it to next after the
+// provisioning delay. Callers hold a.mu.
+func (a *API) transition(s *ServerRecord, status, next string) {
+	if a.opts.ProvisionDelay <= 0 {
+		s.PowerStatus, s.next = next, ""
+		return
+	}
+	s.PowerStatus, s.next = status, next
+	s.readyAt = a.Now().Add(a.opts.ProvisionDelay)
+}
+
+// queue accepts an action on an existing server: s keeps its status for
+// ActionDelay, then goes through transition. Callers hold a.mu.
+func (a *API) queue(s *ServerRecord, status, next string) {
+	if a.opts.ActionDelay <= 0 {
+		a.transition(s, status, next)
+		return
+	}
+	s.pending, s.next = status, next
+	s.startAt = a.Now().Add(a.opts.ActionDelay)
+	s.readyAt = s.startAt.Add(a.opts.ProvisionDelay)
+}
+
+func (a *API) newID() string {
+	a.nextID++
+	return fmt.Sprintf("srv-%04d", a.nextID)
+}
+
+func (a *API) plan(id int) (Plan, bool) {
+	i := slices.IndexFunc(a.catalog.Plans, func(p Plan) bool { return p.ID == id })
+	if i < 0 {
+		return Plan{}, false
+	}
+	return a.catalog.Plans[i], true
+}
+
+func (a *API) location(id int) (Location, bool) {
+	i := slices.IndexFunc(a.catalog.Locations, func(l Location) bool { return l.ID == id })
+	if i < 0 {
+		return Location{}, false
+	}
+	return a.catalog.Locations[i], true
+}
+
+func (a *API) os(id int) (OS, bool) {
+	i := slices.IndexFunc(a.catalog.OperatingSystems, func(o OS) bool { return o.ID == id })
+	if i < 0 {
+		return OS{}, false
+	}
+	return a.catalog.OperatingSystems[i], true
+}
@@ -0,0 +1,100 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func call(t *testing.T, srv *httptest.Server, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-rd-key", "k123")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var env map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		t.Fatalf("%s %s: decode: %v", method, path, err)
+	}
+	return resp.StatusCode, env
+}
+
+func TestAPI_ServerLifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	api := New(Options{APIKey: "k123", ProvisionDelay: time.Minute})
+	api.Now = func() time.Time { return now }
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	status, env := call(t, srv, http.MethodPost, "/v1/ordering/allocate", `{"planId":10,"locationId":1,"osId":62,"raid":1,"hostname":"web-01"}`)
+	if status != http.StatusOK || env["success"] != true {
+		t.Fatalf("allocate: %d %v", status, env)
+	}
+	id := env["data"].(map[string]any)["id"].(string)
+
+	_, env = call(t, srv, http.MethodGet, "/v1/servers/"+id, "")
+	if got := env["data"].(map[string]any)["devicePowerStatus"]; got != StatusProvisioning {
+		t.Fatalf("expected %s right after allocate, got %v", StatusProvisioning, got)
+	}
+
+	now = now.Add(time.Minute)
+	_, env = call(t, srv, http.MethodGet, "/v1/servers/"+id, "")
+	data := env["data"].(map[string]any)
+	if data["devicePowerStatus"] != StatusOn || data["hostname"] != "web-01" || data["monthlyPrice"] != "$79.00" {
+		t.Fatalf("unexpected server after provisioning: %v", data)
+	}
+
+	if status, _ := call(t, srv, http.MethodDelete, "/v1/servers/"+id+"/destroy", ""); status != http.StatusOK {
+		t.Fatalf("destroy: %d", status)
+	}
+	status, env = call(t, srv, http.MethodGet, "/v1/servers/"+id, "")
+	if status != http.StatusNotFound || env["code"] != "not_found" {
+		t.Fatalf("expected not_found after destroy, got %d %v", status, env)
+	}
+}
+
+func TestAPI_Errors(t *testing.T) {
+	srv := httptest.NewServer(New(Options{APIKey: "k123"}))
+	defer srv.Close()
+
+	tests := []struct {
+		name, method, path, body string
+		wantStatus               int
+		wantCode                 string
+	}{
+		{"unknown plan", http.MethodPost, "/v1/ordering/allocate", `{"planId":99,"locationId":1,"osId":62}`, http.StatusUnprocessableEntity, "validation_error"},
+		{"plan not in location", http.MethodPost, "/v1/ordering/allocate", `{"planId":11,"locationId":2,"osId":62}`, http.StatusUnprocessableEntity, "validation_error"},
+		{"unsupported raid", http.MethodGet, "/v1/ordering/plans/10/raid/10/check", "", http.StatusBadRequest, "raid_unsupported"},
+		{"missing server", http.MethodGet, "/v1/servers/nope", "", http.StatusNotFound, "not_found"},
+		{"invalid page", http.MethodGet, "/v1/servers?page=0", "", http.StatusUnprocessableEntity, "validation_error"},
+		{"reverse dns outside account", http.MethodGet, "/v1/reverse-dns/198.51.100.7", "", http.StatusNotFound, "not_found"},
+		{"empty tag key", http.MethodPost, "/v1/ordering/allocate", `{"planId":10,"locationId":1,"osId":62,"tags":{"":"x"}}`, http.StatusUnprocessableEntity, "validation_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, env := call(t, srv, tt.method, tt.path, tt.body)
+			if status != tt.wantStatus || env["code"] != tt.wantCode || env["success"] != false {
+				t.Fatalf("expected %d %s, got %d %v", tt.wantStatus, tt.wantCode, status, env)
+			}
+		})
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/ordering/os", nil)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without API key, got %d", resp.StatusCode)
+	}
+}
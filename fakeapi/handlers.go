@@ -0,0 +1,468 @@
+package fakeapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (a *API) routes() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/ordering/plans", a.listPlans)
+	mux.HandleFunc("GET /v1/ordering/plans/{plan}/raid/{raid}/check", a.checkRaid)
+	mux.HandleFunc("GET /v1/ordering/os", a.listOS)
+	mux.HandleFunc("GET /v1/ordering/locations", a.listLocations)
+	mux.HandleFunc("POST /v1/ordering/allocate", a.allocate)
+	mux.HandleFunc("GET /v1/servers", a.listServers)
+	mux.HandleFunc("GET /v1/servers/{id}", a.getServer)
+	mux.HandleFunc("PATCH /v1/servers/{id}", a.updateServer)
+	mux.HandleFunc("POST /v1/servers/{id}/power", a.power)
+	mux.HandleFunc("POST /v1/servers/{id}/reinstall", a.reinstall)
+	mux.HandleFunc("DELETE /v1/servers/{id}/destroy", a.destroy)
+	mux.HandleFunc("POST /v1/ssh-keys", a.createSSHKey)
+	mux.HandleFunc("GET /v1/ssh-keys", a.listSSHKeys)
+	mux.HandleFunc("GET /v1/ssh-keys/{id}", a.getSSHKey)
+	mux.HandleFunc("DELETE /v1/ssh-keys/{id}", a.deleteSSHKey)
+	mux.HandleFunc("GET /v1/reverse-dns/{ip}", a.getReverseDNS)
+	mux.HandleFunc("PUT /v1/reverse-dns/{ip}", a.setReverseDNS)
+	mux.HandleFunc("DELETE /v1/reverse-dns/{ip}", a.resetReverseDNS)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No route for %s %s", r.Method, r.URL.Path))
+	})
+	a.mux = mux
+}
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "data": data})
+}
+
+// writeCatalog writes a catalog response with an ETag, answering 304 when
+// the client already has it.
+func writeCatalog(w http.ResponseWriter, r *http.Request, data any) {
+	body, _ := json.Marshal(map[string]any{"success": true, "data": data})
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, fields ...fieldError) {
+	body := map[string]any{"success": false, "message": message, "code": code}
+	if len(fields) > 0 {
+		body["errors"] = fields
+	}
+	writeJSON(w, status, body)
+}
+
+func writeValidation(w http.ResponseWriter, fields ...fieldError) {
+	writeError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields...)
+}
+
+func (a *API) listPlans(w http.ResponseWriter, r *http.Request) {
+	keyword := r.URL.Query().Get("location")
+	out := []Plan{}
+	for _, p := range a.catalog.Plans {
+		if keyword != "" {
+			i := slices.IndexFunc(p.Locations, func(l PlanLocation) bool { return strings.EqualFold(l.Keyword, keyword) })
+			if i < 0 {
+				continue
+			}
+			p.Locations = []PlanLocation{p.Locations[i]}
+		}
+		out = append(out, p)
+	}
+	writeCatalog(w, r, out)
+}
+
+func (a *API) checkRaid(w http.ResponseWriter, r *http.Request) {
+	planID, err1 := strconv.Atoi(r.PathValue("plan"))
+	raid, err2 := strconv.Atoi(r.PathValue("raid"))
+	if err1 != nil || err2 != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Plan and RAID must be integers")
+		return
+	}
+	p, ok := a.plan(planID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Plan %d not found", planID))
+		return
+	}
+	if !slices.Contains(p.RaidLevels, raid) {
+		writeError(w, http.StatusBadRequest, "raid_unsupported", fmt.Sprintf("RAID %d is not supported on plan %s", raid, p.Name))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "RAID configuration supported"})
+}
+
+func (a *API) listOS(w http.ResponseWriter, r *http.Request) {
+	writeCatalog(w, r, a.catalog.OperatingSystems)
+}
+
+func (a *API) listLocations(w http.ResponseWriter, r *http.Request) {
+	writeCatalog(w, r, a.catalog.Locations)
+}
+
+type allocateRequest struct {
+	PlanID     int               `json:"planId"`
+	LocationID int               `json:"locationId"`
+	OSID       int               `json:"osId"`
+	Raid       *int              `json:"raid"`
+	Hostname   *string           `json:"hostname"`
+	SSHKeyIDs  []string          `json:"sshKeyIds"`
+	UserData   string            `json:"userData"`
+	Tags       map[string]string `json:"tags"`
+}
+
+func (a *API) allocate(w http.ResponseWriter, r *http.Request) {
+	var req allocateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+
+	var fields []fieldError
+	p, ok := a.plan(req.PlanID)
+	if !ok {
+		fields = append(fields, fieldError{"planId", fmt.Sprintf("plan %d does not exist", req.PlanID)})
+	} else if !slices.ContainsFunc(p.Locations, func(l PlanLocation) bool { return l.ID == req.LocationID }) {
+		fields = append(fields, fieldError{"locationId", fmt.Sprintf("plan %s is not available in location %d", p.Name, req.LocationID)})
+	}
+	if _, ok := a.os(req.OSID); !ok {
+		fields = append(fields, fieldError{"osId", fmt.Sprintf("operating system %d does not exist", req.OSID)})
+	}
+	if req.Raid != nil && ok && !slices.Contains(p.RaidLevels, *req.Raid) {
+		fields = append(fields, fieldError{"raid", fmt.Sprintf("RAID %d is not supported on plan %s", *req.Raid, p.Name)})
+	}
+	userData, err := decodeUserData(req.UserData)
+	if err != nil {
+		fields = append(fields, fieldError{"userData", err.Error()})
+	}
+	if err := checkTags(req.Tags); err != nil {
+		fields = append(fields, fieldError{"tags", err.Error()})
+	}
+	a.mu.Lock()
+	unknownKeys := a.unknownSSHKeys(req.SSHKeyIDs)
+	a.mu.Unlock()
+	if len(unknownKeys) > 0 {
+		fields = append(fields, fieldError{"sshKeyIds", "unknown SSH keys: " + strings.Join(unknownKeys, ", ")})
+	}
+	if len(fields) > 0 {
+		writeValidation(w, fields...)
+		return
+	}
+
+	a.mu.Lock()
+	// A repeated key gets the original response back, even once the server
+	// it ordered is gone.
+	key := r.Header.Get("Idempotency-Key")
+	item, replay := a.orders[key]
+	if !replay || key == "" {
+		s := &ServerRecord{
+			ID:         a.newID(),
+			PlanID:     req.PlanID,
+			LocationID: req.LocationID,
+			OSID:       req.OSID,
+			Raid:       req.Raid,
+			SSHKeyIDs:  req.SSHKeyIDs,
+			UserData:   userData,
+			Tags:       req.Tags,
+			CreatedAt:  a.Now().UTC(),
+		}
+		s.IPAddress = fmt.Sprintf("203.0.113.%d", a.nextID%254+1)
+		if req.Hostname != nil {
+			s.Hostname = *req.Hostname
+		}
+		a.transition(s, StatusProvisioning, StatusOn)
+		a.servers[s.ID] = s
+		a.settle(s)
+		item = a.listItem(s)
+		if key != "" {
+			a.orders[key] = item
+		}
+	}
+	drop := a.dropAllocates > 0
+	if drop {
+		a.dropAllocates--
+	}
+	a.mu.Unlock()
+
+	if drop {
+		if conn, _, err := http.NewResponseController(w).Hijack(); err == nil {
+			conn.Close()
+			return
+		}
+	}
+	writeData(w, item)
+}
+
+func (a *API) listServers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	hostname := q.Get("hostname")
+	maxLimit := a.opts.MaxPageSize
+	if maxLimit <= 0 {
+		maxLimit = defaultMaxPageSize
+	}
+	page, limit := 1, maxLimit
+	var fields []fieldError
+	if v := q.Get("page"); v != "" {
+		if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			fields = append(fields, fieldError{"page", "must be a positive integer"})
+		} else {
+			page = n
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			fields = append(fields, fieldError{"limit", "must be a positive integer"})
+		} else {
+			limit = min(n, maxLimit)
+		}
+	}
+	if len(fields) > 0 {
+		writeValidation(w, fields...)
+		return
+	}
+
+	a.mu.Lock()
+	matched := []*ServerRecord{}
+	for _, rec := range a.sortedServers() {
+		if hostname != "" && rec.Hostname != hostname {
+			continue
+		}
+		matched = append(matched, rec)
+	}
+	out := []map[string]any{}
+	for i := (page - 1) * limit; i < len(matched) && i < page*limit; i++ {
+		a.settle(matched[i])
+		out = append(out, a.serverJSON(matched[i]))
+	}
+	a.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "data": out, "totalCount": len(matched)})
+}
+
+func (a *API) getServer(w http.ResponseWriter, r *http.Request) {
+	a.withServer(w, r, func(s *ServerRecord) {
+		writeData(w, a.serverJSON(s))
+	})
+}
+
+func (a *API) updateServer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Hostname *string           `json:"hostname"`
+		Tags     map[string]string `json:"tags"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+	if err := checkTags(req.Tags); err != nil {
+		writeValidation(w, fieldError{"tags", err.Error()})
+		return
+	}
+	a.withServer(w, r, func(s *ServerRecord) {
+		if req.Hostname != nil {
+			s.Hostname = *req.Hostname
+		}
+		// A present tags object replaces all tags; {} removes them.
+		if req.Tags != nil {
+			s.Tags = req.Tags
+		}
+		writeData(w, a.serverJSON(s))
+	})
+}
+
+func (a *API) power(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Action string `json:"action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+	a.withServer(w, r, func(s *ServerRecord) {
+		if s.next != "" {
+			writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("Server is %s", s.PowerStatus))
+			return
+		}
+		switch req.Action {
+		case "on":
+			a.queue(s, "POWERING_ON", StatusOn)
+		case "reboot":
+			s.Reboots++
+			a.queue(s, "REBOOTING", StatusOn)
+		case "off":
+			a.queue(s, "POWERING_OFF", StatusOff)
+		default:
+			writeValidation(w, fieldError{"action", fmt.Sprintf("unknown action %q", req.Action)})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "Power action queued"})
+	})
+}
+
+func (a *API) reinstall(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OSID      int      `json:"osId"`
+		Raid      *int     `json:"raid"`
+		SSHKeyIDs []string `json:"sshKeyIds"`
+		UserData  string   `json:"userData"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+	if _, ok := a.os(req.OSID); !ok {
+		writeValidation(w, fieldError{"osId", fmt.Sprintf("operating system %d does not exist", req.OSID)})
+		return
+	}
+	userData, err := decodeUserData(req.UserData)
+	if err != nil {
+		writeValidation(w, fieldError{"userData", err.Error()})
+		return
+	}
+	a.withServer(w, r, func(s *ServerRecord) {
+		if unknown := a.unknownSSHKeys(req.SSHKeyIDs); len(unknown) > 0 {
+			writeValidation(w, fieldError{"sshKeyIds", "unknown SSH keys: " + strings.Join(unknown, ", ")})
+			return
+		}
+		s.OSID = req.OSID
+		if req.Raid != nil {
+			s.Raid = req.Raid
+		}
+		s.SSHKeyIDs = req.SSHKeyIDs
+		s.UserData = userData
+		a.queue(s, StatusReinstalling, StatusOn)
+		writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "Reinstall queued"})
+	})
+}
+
+func (a *API) destroy(w http.ResponseWriter, r *http.Request) {
+	a.withServer(w, r, func(s *ServerRecord) {
+		delete(a.servers, s.ID)
+		writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "Server destroyed"})
+	})
+}
+
+// decodeUserData decodes the base64 userData field, which is empty when
+// no user data was sent.
+func decodeUserData(v string) ([]byte, error) {
+	if v == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return nil, fmt.Errorf("must be base64-encoded")
+	}
+	if len(data) > maxUserDataSize {
+		return nil, fmt.Errorf("must be at most %d bytes", maxUserDataSize)
+	}
+	return data, nil
+}
+
+// checkTags validates tag keys and values against the API's limits.
+func checkTags(tags map[string]string) error {
+	for k, v := range tags {
+		switch {
+		case k == "" || len(k) > maxTagKeyLength:
+			return fmt.Errorf("tag keys must be 1 to %d characters", maxTagKeyLength)
+		case len(v) > maxTagValueLength:
+			return fmt.Errorf("tag values must be at most %d characters", maxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// withServer runs fn with a.mu held and the server named by the {id} path
+// value settled, or writes a 404.
+func (a *API) withServer(w http.ResponseWriter, r *http.Request, fn func(*ServerRecord)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.servers[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "Server not found")
+		return
+	}
+	a.settle(s)
+	fn(s)
+}
+
+func (a *API) sortedServers() []*ServerRecord {
+	out := make([]*ServerRecord, 0, len(a.servers))
+	for _, s := range a.servers {
+		out = append(out, s)
+	}
+	slices.SortFunc(out, func(x, y *ServerRecord) int { return strings.Compare(x.ID, y.ID) })
+	return out
+}
+
+func (a *API) listItem(s *ServerRecord) map[string]any {
+	item := map[string]any{
+		"id":          s.ID,
+		"ipAddress":   s.IPAddress,
+		"powerStatus": s.PowerStatus,
+	}
+	if s.Hostname != "" {
+		item["hostname"] = s.Hostname
+	}
+	return item
+}
+
+// serverJSON renders s the way GET /v1/servers/{id} does.
+func (a *API) serverJSON(s *ServerRecord) map[string]any {
+	out := map[string]any{
+		"id":                s.ID,
+		"ipAddress":         s.IPAddress,
+		"devicePowerStatus": s.PowerStatus,
+		"createdAt":         s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.Hostname != "" {
+		out["hostname"] = s.Hostname
+	}
+	if s.Raid != nil {
+		out["raid"] = *s.Raid
+	}
+	if len(s.SSHKeyIDs) > 0 {
+		out["sshKeyIds"] = s.SSHKeyIDs
+	}
+	if len(s.Tags) > 0 {
+		out["tags"] = s.Tags
+	}
+	if p, ok := a.plan(s.PlanID); ok {
+		out["plan"] = map[string]any{
+			"id": p.ID, "name": p.Name, "ram": p.RAMGB, "storage": p.Storage,
+			"cpuName": p.CPU.Name, "cores": p.CPU.Cores,
+		}
+		if i := slices.IndexFunc(p.Locations, func(l PlanLocation) bool { return l.ID == s.LocationID }); i >= 0 {
+			out["monthlyPrice"] = fmt.Sprintf("$%.2f", p.Locations[i].MonthlyPrice)
+		}
+	}
+	if l, ok := a.location(s.LocationID); ok {
+		out["location"] = l
+	}
+	if o, ok := a.os(s.OSID); ok {
+		out["serverOS"] = o
+	}
+	return out
+}
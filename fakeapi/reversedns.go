@@ -0,0 +1,111 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// ptrRe matches a fully qualified domain name, with an optional trailing
+// dot.
+var ptrRe = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// ReverseDNS returns the PTR record of a server IP address.
+func (a *API) ReverseDNS(ip string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := a.serverByIP(ip)
+	if s == nil {
+		return "", false
+	}
+	return ptrOf(s), true
+}
+
+// SetReverseDNS changes a PTR record as if it were edited in the portal.
+// An empty ptr restores the default.
+func (a *API) SetReverseDNS(ip, ptr string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := a.serverByIP(ip)
+	if s == nil {
+		return false
+	}
+	s.PTR = ptr
+	return true
+}
+
+// serverByIP finds the server with the given IP address. Callers hold a.mu.
+func (a *API) serverByIP(ip string) *ServerRecord {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+	for _, s := range a.servers {
+		if sa, err := netip.ParseAddr(s.IPAddress); err == nil && sa == addr {
+			return s
+		}
+	}
+	return nil
+}
+
+// ptrOf returns the server's PTR, or the generated default when none was
+// set.
+func ptrOf(s *ServerRecord) string {
+	if s.PTR != "" {
+		return s.PTR
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(s.IPAddress) + ".static.rackdog.net"
+}
+
+func reverseDNSJSON(s *ServerRecord) map[string]any {
+	return map[string]any{
+		"ipAddress": s.IPAddress,
+		"ptr":       ptrOf(s),
+		"serverId":  s.ID,
+	}
+}
+
+// withIP runs fn on the server owning the {ip} path value, answering 404
+// when no server in the account has it.
+func (a *API) withIP(w http.ResponseWriter, r *http.Request, fn func(*ServerRecord)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := a.serverByIP(r.PathValue("ip"))
+	if s == nil {
+		writeError(w, http.StatusNotFound, "not_found", "No server in this account has IP address "+r.PathValue("ip"))
+		return
+	}
+	fn(s)
+}
+
+func (a *API) getReverseDNS(w http.ResponseWriter, r *http.Request) {
+	a.withIP(w, r, func(s *ServerRecord) { writeData(w, reverseDNSJSON(s)) })
+}
+
+func (a *API) setReverseDNS(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PTR string `json:"ptr"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+	if len(req.PTR) > 254 || !ptrRe.MatchString(req.PTR) {
+		writeValidation(w, fieldError{"ptr", "must be a fully qualified domain name"})
+		return
+	}
+	a.withIP(w, r, func(s *ServerRecord) {
+		s.PTR = req.PTR
+		writeData(w, reverseDNSJSON(s))
+	})
+}
+
+// resetReverseDNS restores the default PTR.
+func (a *API) resetReverseDNS(w http.ResponseWriter, r *http.Request) {
+	a.withIP(w, r, func(s *ServerRecord) {
+		s.PTR = ""
+		writeData(w, reverseDNSJSON(s))
+	})
+}
@@ -0,0 +1,157 @@
+package fakeapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SSHKeyRecord is the fake's view of one account SSH key.
+type SSHKeyRecord struct {
+	ID          string
+	Name        string
+	PublicKey   string
+	Fingerprint string
+	CreatedAt   time.Time
+}
+
+// SSHKey returns a copy of the SSH key with the given ID.
+func (a *API) SSHKey(id string) (SSHKeyRecord, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	k, ok := a.sshKeys[id]
+	if !ok {
+		return SSHKeyRecord{}, false
+	}
+	return *k, true
+}
+
+// RemoveSSHKey deletes an SSH key as if it were deleted in the portal.
+func (a *API) RemoveSSHKey(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.sshKeys[id]
+	delete(a.sshKeys, id)
+	return ok
+}
+
+// sshKeyFingerprint parses an authorized_keys line ("type base64 [comment]")
+// and returns its OpenSSH SHA256 fingerprint.
+func sshKeyFingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("expected \"<type> <base64> [comment]\"")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("key data is not valid base64")
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+func sshKeyJSON(k *SSHKeyRecord) map[string]any {
+	return map[string]any{
+		"id":          k.ID,
+		"name":        k.Name,
+		"publicKey":   k.PublicKey,
+		"fingerprint": k.Fingerprint,
+		"createdAt":   k.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (a *API) createSSHKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name      string `json:"name"`
+		PublicKey string `json:"publicKey"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON body: "+err.Error())
+		return
+	}
+	var fields []fieldError
+	if strings.TrimSpace(req.Name) == "" {
+		fields = append(fields, fieldError{"name", "must not be empty"})
+	}
+	fingerprint, err := sshKeyFingerprint(req.PublicKey)
+	if err != nil {
+		fields = append(fields, fieldError{"publicKey", err.Error()})
+	}
+	if len(fields) > 0 {
+		writeValidation(w, fields...)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, k := range a.sshKeys {
+		if k.Fingerprint == fingerprint {
+			writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("SSH key already exists as %s", k.ID))
+			return
+		}
+	}
+	a.nextKeyID++
+	k := &SSHKeyRecord{
+		ID:          fmt.Sprintf("key-%04d", a.nextKeyID),
+		Name:        req.Name,
+		PublicKey:   strings.TrimSpace(req.PublicKey),
+		Fingerprint: fingerprint,
+		CreatedAt:   a.Now().UTC(),
+	}
+	a.sshKeys[k.ID] = k
+	writeData(w, sshKeyJSON(k))
+}
+
+func (a *API) listSSHKeys(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	out := []map[string]any{}
+	ids := make([]string, 0, len(a.sshKeys))
+	for id := range a.sshKeys {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		out = append(out, sshKeyJSON(a.sshKeys[id]))
+	}
+	a.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "data": out, "totalCount": len(out)})
+}
+
+func (a *API) getSSHKey(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	k, ok := a.sshKeys[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "SSH key not found")
+		return
+	}
+	writeData(w, sshKeyJSON(k))
+}
+
+func (a *API) deleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := a.sshKeys[id]; !ok {
+		writeError(w, http.StatusNotFound, "not_found", "SSH key not found")
+		return
+	}
+	delete(a.sshKeys, id)
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "SSH key deleted"})
+}
+
+// unknownSSHKeys returns the IDs that name no SSH key. Callers hold a.mu.
+func (a *API) unknownSSHKeys(ids []string) []string {
+	var out []string
+	for _, id := range ids {
+		if _, ok := a.sshKeys[id]; !ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
+)
+
+// Acceptance tests run resource.Test steps through a Terraform binary, with
+// the provider served in process. By default the provider talks to
+// fakeapi; set RACKDOG_ENDPOINT and RACKDOG_API_KEY to run against a real
+// account instead.
+
+// testAccProtoV6ProviderFactories serves the provider in process to the
+// Terraform binary terraform-plugin-testing drives.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"rackdog": providerserver.NewProtocol6WithError(New("acc")()),
+}
+
+// testAccPreCheck skips acceptance tests unless TF_ACC is set and a
+// Terraform binary is available without a download.
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("acceptance tests skipped unless TF_ACC is set")
+	}
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("acceptance tests need Terraform 1.11 or later: put terraform on PATH or set TF_ACC_TERRAFORM_PATH")
+	}
+}
+
+// accEnv is the API an acceptance test runs against.
+type accEnv struct {
+	t *testing.T
+	// fake is nil when running against a real endpoint.
+	fake *fakeapi.API
+	// client talks to the same API as the provider, for checks.
+	client   *Client
+	endpoint string
+	apiKey   string
+}
+
+// newAccEnv skips unless acceptance tests are enabled and starts a fake API
+// unless RACKDOG_ENDPOINT is set.
+func newAccEnv(t *testing.T) *accEnv {
+	t.Helper()
+	testAccPreCheck(t)
+
+	old := serverPollInterval
+	serverPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { serverPollInterval = old })
+
+	e := &accEnv{t: t}
+	if endpoint := os.Getenv("RACKDOG_ENDPOINT"); endpoint != "" {
+		e.endpoint, e.apiKey = endpoint, os.Getenv("RACKDOG_API_KEY")
+	} else {
+		// The fake shows servers as soon as they are ordered, so a
+		// replayed order whose server is gone need not be waited out.
+		oldVisible := orderVisibleTimeout
+		orderVisibleTimeout = 50 * time.Millisecond
+		t.Cleanup(func() { orderVisibleTimeout = oldVisible })
+
+		e.fake = fakeapi.New(fakeapi.Options{APIKey: "acc-key", ProvisionDelay: 30 * time.Millisecond, ActionDelay: 20 * time.Millisecond})
+		srv := httptest.NewServer(e.fake)
+		t.Cleanup(srv.Close)
+		e.endpoint, e.apiKey = srv.URL, "acc-key"
+	}
+	e.client = NewClient(e.endpoint, e.apiKey)
+	return e
+}
+
+// requireFake skips tests that manipulate API state directly.
+func (e *accEnv) requireFake() {
+	e.t.Helper()
+	if e.fake == nil {
+		e.t.Skip("test needs the fake API")
+	}
+}
+
+// config returns a Terraform configuration: a provider block with the
+// given attributes (endpoint and api_key are filled in), followed by
+// blocks.
+func (e *accEnv) config(providerAttrs map[string]any, blocks ...string) string {
+	attrs := map[string]any{}
+	if e.fake != nil {
+		attrs["endpoint"], attrs["api_key"] = e.endpoint, e.apiKey
+	}
+	maps.Copy(attrs, providerAttrs)
+	var b strings.Builder
+	b.WriteString("provider \"rackdog\" ")
+	writeHCLBody(&b, attrs, "")
+	for _, block := range blocks {
+		b.WriteString("\n")
+		b.WriteString(block)
+	}
+	return b.String()
+}
+
+// hclExpr is written into a config as is, such as a reference to another
+// resource.
+type hclExpr string
+
+// hclBlock is written as a nested block rather than an attribute.
+type hclBlock map[string]any
+
+// testAccResource renders a resource block.
+func testAccResource(typeName, name string, attrs map[string]any) string {
+	return testAccBlock("resource", typeName, name, attrs)
+}
+
+// testAccDataSource renders a data block.
+func testAccDataSource(typeName, name string, attrs map[string]any) string {
+	return testAccBlock("data", typeName, name, attrs)
+}
+
+func testAccBlock(kind, typeName, name string, attrs map[string]any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q %q ", kind, typeName, name)
+	writeHCLBody(&b, attrs, "")
+	return b.String()
+}
+
+// writeHCLBody writes attrs as a block body, in key order. Nil values are
+// left out.
+func writeHCLBody(b *strings.Builder, attrs map[string]any, indent string) {
+	b.WriteString("{\n")
+	for _, k := range slices.Sorted(maps.Keys(attrs)) {
+		switch v := attrs[k].(type) {
+		case nil:
+		case hclBlock:
+			fmt.Fprintf(b, "%s  %s ", indent, k)
+			writeHCLBody(b, v, indent+"  ")
+		default:
+			fmt.Fprintf(b, "%s  %s = %s\n", indent, k, hclValue(v))
+		}
+	}
+	b.WriteString(indent + "}\n")
+}
+
+func hclValue(v any) string {
+	switch v := v.(type) {
+	case hclExpr:
+		return string(v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		q := make([]string, len(v))
+		for i, s := range v {
+			q[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(q, ", ") + "]"
+	case map[string]string:
+		var pairs []string
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			pairs = append(pairs, fmt.Sprintf("%q = %q", k, v[k]))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// testAccID copies the ID of the resource at addr into id, for later steps.
+func testAccID(addr string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[addr]
+		if !ok {
+			return fmt.Errorf("%s not in state", addr)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// checkFake runs fn against the fake API's record of the server at addr.
+// It does nothing against a real endpoint.
+func (e *accEnv) checkFake(addr string, fn func(fakeapi.ServerRecord) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if e.fake == nil {
+			return nil
+		}
+		rs, ok := s.RootModule().Resources[addr]
+		if !ok {
+			return fmt.Errorf("%s not in state", addr)
+		}
+		rec, ok := e.fake.Server(rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("server %s of %s not in the API", rs.Primary.ID, addr)
+		}
+		return fn(rec)
+	}
+}
+
+// checkDestroy fails if a server or SSH key left in state still exists.
+func (e *accEnv) checkDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	for addr, rs := range s.RootModule().Resources {
+		var err error
+		switch rs.Type {
+		case "rackdog_server":
+			_, err = e.client.GetServer(ctx, rs.Primary.ID)
+		case "rackdog_ssh_key":
+			_, err = e.client.GetSSHKey(ctx, rs.Primary.ID)
+		default:
+			continue
+		}
+		if err == nil {
+			return fmt.Errorf("%s (%s) still exists after destroy", addr, rs.Primary.ID)
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultCatalogTTL is how long catalog responses are reused before they
+// are revalidated.
+const defaultCatalogTTL = 5 * time.Minute
+
+// catalog caches the ordering catalog (plans, operating systems and
+// locations) for one configured provider, so the data sources and plan-time
+// validators in a run share fetches. Concurrent lookups of the same
+// endpoint wait for a single request, and expired entries are revalidated
+// with If-None-Match when the API sent an ETag.
+type catalog struct {
+	client *Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*catalogEntry
+	calls   map[string]*catalogCall
+}
+
+type catalogEntry struct {
+	value   any
+	etag    string
+	fetched time.Time
+}
+
+// catalogCall is a fetch in flight; done is closed once value and err are
+// set.
+type catalogCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newCatalog(c *Client, ttl time.Duration) *catalog {
+	return &catalog{
+		client:  c,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*catalogEntry{},
+		calls:   map[string]*catalogCall{},
+	}
+}
+
+func (c *catalog) ListPlans(ctx context.Context, location string) ([]Plan, error) {
+	v, err := c.get(ctx, plansPath(location), func(ctx context.Context, path, etag string) (any, string, bool, error) {
+		var env EnvelopePlans
+		etag, notModified, err := c.client.getConditional(ctx, path, etag, &env)
+		return env.Data, etag, notModified, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]Plan)), nil
+}
+
+func (c *catalog) ListOperatingSystems(ctx context.Context) ([]ServerOS, error) {
+	v, err := c.get(ctx, osPath, func(ctx context.Context, path, etag string) (any, string, bool, error) {
+		var env EnvelopeOS
+		etag, notModified, err := c.client.getConditional(ctx, path, etag, &env)
+		return env.Data, etag, notModified, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]ServerOS)), nil
+}
+
+func (c *catalog) ListLocations(ctx context.Context) ([]ServerLocation, error) {
+	v, err := c.get(ctx, locationsPath, func(ctx context.Context, path, etag string) (any, string, bool, error) {
+		var env EnvelopeLocations
+		etag, notModified, err := c.client.getConditional(ctx, path, etag, &env)
+		return env.Data, etag, notModified, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]ServerLocation)), nil
+}
+
+// get returns the cached value for path, fetching it when missing or
+// expired. fetch receives the cached ETag and reports notModified when the
+// cached value is still current. Errors are not cached.
+func (c *catalog) get(ctx context.Context, path string, fetch func(ctx context.Context, path, etag string) (value any, newETag string, notModified bool, err error)) (any, error) {
+	c.mu.Lock()
+	entry := c.entries[path]
+	if entry != nil && c.now().Sub(entry.fetched) < c.ttl {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	if call, ok := c.calls[path]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &catalogCall{done: make(chan struct{})}
+	c.calls[path] = call
+	c.mu.Unlock()
+
+	var etag string
+	if entry != nil {
+		etag = entry.etag
+	}
+	value, newETag, notModified, err := fetch(ctx, path, etag)
+	if notModified {
+		value = entry.value
+	}
+
+	c.mu.Lock()
+	if err == nil {
+		c.entries[path] = &catalogEntry{value: value, etag: newETag, fetched: c.now()}
+	}
+	delete(c.calls, path)
+	call.value, call.err = value, err
+	c.mu.Unlock()
+	close(call.done)
+	return value, err
+}
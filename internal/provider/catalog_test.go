@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCatalog_DedupesConcurrentFetches(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": []map[string]any{{"id": 62, "name": "Ubuntu 24.04"}}})
+	}))
+	defer srv.Close()
+	cat := newCatalog(NewClient(srv.URL, "k123"), time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			osList, err := cat.ListOperatingSystems(context.Background())
+			if err == nil && len(osList) != 1 {
+				t.Errorf("expected 1 OS, got %d", len(osList))
+			}
+			errs <- err
+		}()
+	}
+	// Let the goroutines queue up behind the first request.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+}
+
+func TestCatalog_TTLAndETag(t *testing.T) {
+	var calls, notModified atomic.Int32
+	fail := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": []map[string]any{{"id": 1, "keyword": "ny"}}})
+	}))
+	defer srv.Close()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cat := newCatalog(NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{})), time.Minute)
+	cat.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := cat.ListLocations(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected 1 request within the TTL, got %d", n)
+	}
+
+	now = now.Add(2 * time.Minute)
+	locs, err := cat.ListLocations(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if notModified.Load() != 1 || len(locs) != 1 || locs[0].Keyword != "ny" {
+		t.Fatalf("expected a 304 revalidation to keep the cached locations, got %v (304s: %d)", locs, notModified.Load())
+	}
+
+	// Errors are returned but not cached.
+	now = now.Add(2 * time.Minute)
+	fail.Store(true)
+	if _, err := cat.ListLocations(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+	fail.Store(false)
+	if _, err := cat.ListLocations(ctx); err != nil {
+		t.Fatalf("expected the next call to refetch, got %v", err)
+	}
+}
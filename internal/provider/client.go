@@ -6,78 +6,271 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-type HTTPError struct {
-	Status int
-	Method string
-	URL    string
-	Body   string
+// RetryPolicy controls how Client.do retries failed requests. Requests are
+// retried with exponential backoff and jitter between MinWait and MaxWait,
+// or after the delay the API asks for with Retry-After.
+type RetryPolicy struct {
+	MaxRetries int
+	MinWait    time.Duration
+	MaxWait    time.Duration
+	// RetryNonIdempotent also retries POST and PATCH requests after
+	// transient failures. A 429 is always retried since the request was
+	// rejected before it was processed.
+	RetryNonIdempotent bool
 }
 
-func (e *HTTPError) Error() string {
-	return fmt.Sprintf("%s %s failed: %d - %s", e.Method, e.URL, e.Status, e.Body)
+// maxRetryAfter is the longest Retry-After the client waits out. Requests
+// without a deadline, like refreshes, would otherwise block for as long as
+// the API asks.
+const maxRetryAfter = 5 * time.Minute
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	MinWait:    time.Second,
+	MaxWait:    30 * time.Second,
 }
 
 type Client struct {
 	base   string
 	apiKey string
 	http   *http.Client
+	retry  RetryPolicy
+}
+
+type ClientOption func(*Client)
+
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) { c.retry = p }
 }
 
-func NewClient(base, apiKey string) *Client {
-	return &Client{
+func NewClient(base, apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
 		base:   strings.TrimRight(base, "/"),
 		apiKey: apiKey,
 		http:   &http.Client{Timeout: 30 * time.Second},
+		retry:  DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *Client) do(ctx context.Context, method, path string, body any, out any) error {
+	_, err := c.doWithHeaders(ctx, method, path, nil, body, out)
+	return err
+}
+
+// doWithHeaders is do with extra request headers, returning the response
+// headers. A request carrying an Idempotency-Key is retried after transient
+// failures like an idempotent method, since the API deduplicates it.
+func (c *Client) doWithHeaders(ctx context.Context, method, path string, header http.Header, body any, out any) (http.Header, error) {
 	u := c.base + path
+	ctx = c.httpLogContext(ctx)
+	requestID := newRequestID()
 
-	var rdr io.Reader
+	var payload []byte
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		payload = b
+	}
+
+	for attempt := 0; ; attempt++ {
+		respHeader, transient, err := c.doOnce(ctx, method, u, requestID, header, payload, out)
+		if err == nil {
+			return respHeader, nil
+		}
+
+		retry := false
+		ae, _ := asAPIError(err)
+		if ae != nil && ae.Status == http.StatusTooManyRequests {
+			retry = true
+		} else if transient {
+			retry = isIdempotent(method) || header.Get(idempotencyKeyHeader) != "" || c.retry.RetryNonIdempotent
+		}
+		if !retry || attempt >= c.retry.MaxRetries || ctx.Err() != nil {
+			return respHeader, err
+		}
+
+		var retryAfter time.Duration
+		if ae != nil {
+			retryAfter = ae.RetryAfter
+		}
+		if retryAfter > maxRetryAfter {
+			return respHeader, fmt.Errorf("%w (the API asked to retry after %s, longer than the %s the provider waits)", err, retryAfter, maxRetryAfter)
+		}
+		wait := c.retry.backoff(attempt, retryAfter)
+		// Retrying early would only earn another 429, so give up now if the
+		// API asks for longer than the operation has left.
+		if deadline, ok := ctx.Deadline(); ok && retryAfter > 0 && time.Until(deadline) < wait {
+			return respHeader, fmt.Errorf("%w (the API asked to retry after %s, beyond the operation's deadline)", err, retryAfter)
+		}
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Retrying Rackdog API request", map[string]any{
+			"method":     method,
+			"url":        u,
+			"request_id": requestID,
+			"attempt":    attempt + 1,
+			"wait":       wait.String(),
+			"error":      err.Error(),
+		})
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return respHeader, err
+		case <-t.C:
 		}
-		rdr = bytes.NewReader(b)
+	}
+}
+
+// doOnce performs a single request and logs it to the rackdog_http
+// subsystem. transient reports whether the failure may succeed if retried
+// (network errors, 502, 503 and 504).
+func (c *Client) doOnce(ctx context.Context, method, u, requestID string, header http.Header, payload []byte, out any) (respHeader http.Header, transient bool, err error) {
+	var rdr io.Reader
+	if payload != nil {
+		rdr = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u, rdr)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
 	// Header name per your middleware note:
 	req.Header.Set("x-rd-key", c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
+	if requestID != "" {
+		req.Header.Set("X-Request-Id", requestID)
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
 
+	fields := map[string]any{
+		"method":       method,
+		"path":         req.URL.RequestURI(),
+		"request_id":   requestID,
+		"request_body": redactBody(payload),
+	}
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Sending Rackdog API request", fields)
+
+	start := time.Now()
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return err
+		fields["latency_ms"] = time.Since(start).Milliseconds()
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Rackdog API request failed", fields)
+		return nil, true, err
 	}
 	defer resp.Body.Close()
 
+	b, err := io.ReadAll(resp.Body)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	fields["status"] = resp.StatusCode
+	fields["response_body"] = redactBody(b)
+	if id := resp.Header.Get("X-Request-Id"); id != "" && id != requestID {
+		fields["server_request_id"] = id
+	}
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Received Rackdog API response", fields)
+	if err != nil {
+		return resp.Header, true, err
+	}
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		if out != nil {
-			return json.NewDecoder(resp.Body).Decode(out)
+		// Some endpoints report failures as 200 with success=false.
+		var env errorEnvelope
+		if json.Unmarshal(b, &env) == nil && env.Success != nil && !*env.Success {
+			aerr := &APIError{Status: resp.StatusCode, Method: method, URL: u, Body: string(b)}
+			aerr.decodeEnvelope(b)
+			return resp.Header, false, aerr
 		}
-		return nil
+		if out != nil && len(b) > 0 {
+			return resp.Header, false, json.Unmarshal(b, out)
+		}
+		return resp.Header, false, nil
+	}
+
+	aerr := &APIError{
+		Status:     resp.StatusCode,
+		Method:     method,
+		URL:        u,
+		Body:       string(b),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 	}
+	aerr.decodeEnvelope(b)
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return resp.Header, true, aerr
+	}
+	return resp.Header, false, aerr
+}
 
-	b, _ := io.ReadAll(resp.Body)
-	return &HTTPError{
-		Status: resp.StatusCode,
-		Method: method,
-		URL:    u,
-		Body:   string(b),
+const idempotencyKeyHeader = "Idempotency-Key"
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
 	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt+1. A Retry-After
+// from the server is honoured in full, even beyond MaxWait; MaxWait only
+// caps the computed delay. Callers reject a Retry-After beyond
+// maxRetryAfter before asking.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	d := p.MinWait << attempt
+	if d <= 0 || d > p.MaxWait {
+		d = p.MaxWait
+	}
+	// Equal jitter: half fixed, half random, so parallel applies spread out.
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
+
+// parseRetryAfter understands both forms of the header: delay-seconds and
+// an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 type JobStatus struct {
@@ -112,18 +305,47 @@ type CreateServerRequest struct {
 	OSID       int     `json:"osId"`
 	Raid       *int    `json:"raid,omitempty"`
 	Hostname   *string `json:"hostname,omitempty"`
+	// SSHKeyIDs are installed into the root account's authorized_keys.
+	SSHKeyIDs []string `json:"sshKeyIds,omitempty"`
+	// UserData is base64-encoded cloud-init user data.
+	UserData string            `json:"userData,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	// IdempotencyKey is sent as the Idempotency-Key header. Repeating an
+	// allocate with the same key returns the original order instead of
+	// ordering again.
+	IdempotencyKey string `json:"-"`
+}
+
+// UpdateServerRequest carries the server fields that can change in place.
+// Nil fields are left untouched.
+type UpdateServerRequest struct {
+	Hostname *string `json:"hostname,omitempty"`
+	// Tags replaces all tags. A non-nil empty map removes them.
+	Tags map[string]string `json:"tags,omitzero"`
+}
+
+// ReinstallServerRequest reinstalls the OS on an existing server, keeping
+// its hardware and IP address.
+type ReinstallServerRequest struct {
+	OSID      int      `json:"osId"`
+	Raid      *int     `json:"raid,omitempty"`
+	SSHKeyIDs []string `json:"sshKeyIds,omitempty"`
+	UserData  string   `json:"userData,omitempty"`
 }
 
 type Server struct {
-	ID           string         `json:"id,omitempty"`
-	Plan         ServerPlan     `json:"plan"`
-	Location     ServerLocation `json:"location"`
-	ServerOS     *ServerOS      `json:"serverOS,omitempty"`
-	Raid         *int           `json:"raid,omitempty"`
-	Hostname     *string        `json:"hostname,omitempty"`
-	IPAddress    string         `json:"ipAddress,omitempty"`
-	PowerStatus  *string        `json:"devicePowerStatus,omitempty"`
-	MonthlyPrice *string        `json:"monthlyPrice,omitempty"`
+	ID           string            `json:"id,omitempty"`
+	Plan         ServerPlan        `json:"plan"`
+	Location     ServerLocation    `json:"location"`
+	ServerOS     *ServerOS         `json:"serverOS,omitempty"`
+	Raid         *int              `json:"raid,omitempty"`
+	Hostname     *string           `json:"hostname,omitempty"`
+	IPAddress    string            `json:"ipAddress,omitempty"`
+	PowerStatus  *string           `json:"devicePowerStatus,omitempty"`
+	MonthlyPrice *string           `json:"monthlyPrice,omitempty"`
+	SSHKeyIDs    []string          `json:"sshKeyIds,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	CreatedAt    string            `json:"createdAt,omitempty"`
 }
 
 type ServerListItem struct {
@@ -140,10 +362,10 @@ type CPU struct {
 }
 
 type PlanLocation struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	Keyword      string `json:"keyword"`
-	MonthlyPrice int    `json:"monthlyPrice"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Keyword      string  `json:"keyword"`
+	MonthlyPrice float64 `json:"monthlyPrice"`
 }
 
 type Plan struct {
@@ -153,6 +375,7 @@ type Plan struct {
 	Locations []PlanLocation `json:"locations"`
 	RAMGB     int            `json:"ram"`
 	Storage   int            `json:"storageGb"`
+	CreatedAt string         `json:"createdAt,omitempty"`
 }
 
 // //////
@@ -165,6 +388,13 @@ type EnvelopeServer struct {
 	TotalCount int    `json:"totalCount,omitempty"`
 }
 
+type EnvelopeServers struct {
+	Success    bool     `json:"success"`
+	Data       []Server `json:"data"`
+	Message    string   `json:"message"`
+	TotalCount int      `json:"totalCount,omitempty"`
+}
+
 type EnvelopeServerListItem struct {
 	Success    bool           `json:"success"`
 	Data       ServerListItem `json:"data"`
@@ -172,6 +402,18 @@ type EnvelopeServerListItem struct {
 	TotalCount int            `json:"totalCount,omitempty"`
 }
 
+type EnvelopeSSHKey struct {
+	Success bool   `json:"success"`
+	Data    SSHKey `json:"data"`
+	Message string `json:"message"`
+}
+
+type EnvelopeReverseDNS struct {
+	Success bool       `json:"success"`
+	Data    ReverseDNS `json:"data"`
+	Message string     `json:"message"`
+}
+
 type EnvelopePlans struct {
 	Success bool   `json:"success"`
 	Data    []Plan `json:"data"`
@@ -183,6 +425,12 @@ type EnvelopeRaidCheck struct {
 	Message string `json:"message"`
 }
 
+type EnvelopeLocations struct {
+	Success bool             `json:"success"`
+	Data    []ServerLocation `json:"data"`
+	Message string           `json:"message"`
+}
+
 type EnvelopeOS struct {
 	Success bool       `json:"success"`
 	Data    []ServerOS `json:"data"`
@@ -191,11 +439,12 @@ type EnvelopeOS struct {
 
 func (c *Client) CreateServer(ctx context.Context, reqBody *CreateServerRequest) (*ServerListItem, error) {
 	var env EnvelopeServerListItem
-	if err := c.do(ctx, http.MethodPost, "/v1/ordering/allocate", reqBody, &env); err != nil {
-		return nil, err
+	var header http.Header
+	if reqBody.IdempotencyKey != "" {
+		header = http.Header{idempotencyKeyHeader: {reqBody.IdempotencyKey}}
 	}
-	if !env.Success {
-		return nil, fmt.Errorf("%s", env.Message)
+	if _, err := c.doWithHeaders(ctx, http.MethodPost, "/v1/ordering/allocate", header, reqBody, &env); err != nil {
+		return nil, err
 	}
 	out := env.Data
 	return &out, nil
@@ -206,30 +455,221 @@ func (c *Client) GetServer(ctx context.Context, id string) (*Server, error) {
 	if err := c.do(ctx, http.MethodGet, "/v1/servers/"+url.PathEscape(id), nil, &env); err != nil {
 		return nil, err
 	}
-	if !env.Success {
-		return nil, fmt.Errorf("%s", env.Message)
+	out := env.Data
+	return &out, nil
+}
+
+// Power actions accepted by PowerAction.
+const (
+	PowerActionOn     = "on"
+	PowerActionOff    = "off"
+	PowerActionReboot = "reboot"
+)
+
+type powerActionRequest struct {
+	Action string `json:"action"`
+}
+
+// PowerAction asks the API to power a server on, off or reboot it. The call
+// returns once the request is accepted; poll GetServer for the outcome.
+func (c *Client) PowerAction(ctx context.Context, id, action string) error {
+	return c.do(ctx, http.MethodPost, "/v1/servers/"+url.PathEscape(id)+"/power", &powerActionRequest{Action: action}, nil)
+}
+
+// ReinstallServer starts an OS reinstall. Poll GetServer until the server
+// is ready again.
+func (c *Client) ReinstallServer(ctx context.Context, id string, reqBody *ReinstallServerRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/servers/"+url.PathEscape(id)+"/reinstall", reqBody, nil)
+}
+
+func (c *Client) UpdateServer(ctx context.Context, id string, reqBody *UpdateServerRequest) (*Server, error) {
+	var env EnvelopeServer
+	if err := c.do(ctx, http.MethodPatch, "/v1/servers/"+url.PathEscape(id), reqBody, &env); err != nil {
+		return nil, err
 	}
 	out := env.Data
 	return &out, nil
 }
 
+// serverPageSize is the page size ListServers requests.
+const serverPageSize = 100
+
+// maxServerPages bounds ListServers, so an API that ignores the page
+// parameter fails the call instead of looping forever.
+var maxServerPages = 1000
+
+// ListServersOptions narrows ListServers on the API side.
+type ListServersOptions struct {
+	// Hostname, if set, is passed as the hostname query parameter.
+	Hostname string
+}
+
+// ListServers returns every server in the account, following pages until
+// totalCount servers were read or a page comes back empty. It fails when a
+// page repeats the previous one or after maxServerPages pages.
+func (c *Client) ListServers(ctx context.Context, opts ListServersOptions) ([]Server, error) {
+	var all, prev []Server
+	for page := 1; ; page++ {
+		if page > maxServerPages {
+			return nil, fmt.Errorf("listing servers: gave up after %d pages of %d", maxServerPages, serverPageSize)
+		}
+		q := url.Values{}
+		if opts.Hostname != "" {
+			q.Set("hostname", opts.Hostname)
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(serverPageSize))
+
+		var env EnvelopeServers
+		if err := c.do(ctx, http.MethodGet, "/v1/servers?"+q.Encode(), nil, &env); err != nil {
+			return nil, err
+		}
+		if len(env.Data) > 0 && slices.EqualFunc(env.Data, prev, func(a, b Server) bool { return a.ID == b.ID }) {
+			return nil, fmt.Errorf("listing servers: page %d repeats page %d; the API appears to ignore the page parameter", page, page-1)
+		}
+		prev = env.Data
+		all = append(all, env.Data...)
+		switch {
+		case len(env.Data) == 0:
+			return all, nil
+		case env.TotalCount > 0:
+			if len(all) >= env.TotalCount {
+				return all, nil
+			}
+		case len(env.Data) < serverPageSize:
+			// Without a totalCount, a short page is the last one.
+			return all, nil
+		}
+	}
+}
+
+// ListServersByHostname returns the servers whose hostname matches
+// exactly.
+func (c *Client) ListServersByHostname(ctx context.Context, hostname string) ([]Server, error) {
+	servers, err := c.ListServers(ctx, ListServersOptions{Hostname: hostname})
+	if err != nil {
+		return nil, err
+	}
+	var found []Server
+	for _, s := range servers {
+		if s.Hostname != nil && *s.Hostname == hostname {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+// FindServerByHostname returns the single server whose hostname matches
+// exactly. It errors when none or more than one server matches.
+func (c *Client) FindServerByHostname(ctx context.Context, hostname string) (*Server, error) {
+	found, err := c.ListServersByHostname(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no server with hostname %q", hostname)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("%d servers have hostname %q; import by ID instead", len(found), hostname)
+	}
+}
+
 func (c *Client) DeleteServer(ctx context.Context, id string) error {
 	return c.do(ctx, http.MethodDelete, "/v1/servers/"+url.PathEscape(id)+"/destroy", nil, nil)
 }
 
+// SSHKey is a public key stored in the account, which can be installed
+// on servers at allocate or reinstall time.
+type SSHKey struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	PublicKey   string `json:"publicKey"`
+	Fingerprint string `json:"fingerprint"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+}
+
+type CreateSSHKeyRequest struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey"`
+}
+
+func (c *Client) CreateSSHKey(ctx context.Context, reqBody *CreateSSHKeyRequest) (*SSHKey, error) {
+	var env EnvelopeSSHKey
+	if err := c.do(ctx, http.MethodPost, "/v1/ssh-keys", reqBody, &env); err != nil {
+		return nil, err
+	}
+	out := env.Data
+	return &out, nil
+}
+
+func (c *Client) GetSSHKey(ctx context.Context, id string) (*SSHKey, error) {
+	var env EnvelopeSSHKey
+	if err := c.do(ctx, http.MethodGet, "/v1/ssh-keys/"+url.PathEscape(id), nil, &env); err != nil {
+		return nil, err
+	}
+	out := env.Data
+	return &out, nil
+}
+
+func (c *Client) DeleteSSHKey(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/ssh-keys/"+url.PathEscape(id), nil, nil)
+}
+
+// ReverseDNS is the PTR record of a server IP address. Every IP has one;
+// until it is set, the API serves a generated default name.
+type ReverseDNS struct {
+	IPAddress string `json:"ipAddress"`
+	PTR       string `json:"ptr"`
+	ServerID  string `json:"serverId"`
+}
+
+type SetReverseDNSRequest struct {
+	PTR string `json:"ptr"`
+}
+
+func reverseDNSPath(ip string) string {
+	return "/v1/reverse-dns/" + url.PathEscape(ip)
+}
+
+func (c *Client) GetReverseDNS(ctx context.Context, ip string) (*ReverseDNS, error) {
+	var env EnvelopeReverseDNS
+	if err := c.do(ctx, http.MethodGet, reverseDNSPath(ip), nil, &env); err != nil {
+		return nil, err
+	}
+	out := env.Data
+	return &out, nil
+}
+
+func (c *Client) SetReverseDNS(ctx context.Context, ip, ptr string) (*ReverseDNS, error) {
+	var env EnvelopeReverseDNS
+	if err := c.do(ctx, http.MethodPut, reverseDNSPath(ip), &SetReverseDNSRequest{PTR: ptr}, &env); err != nil {
+		return nil, err
+	}
+	out := env.Data
+	return &out, nil
+}
+
+// ResetReverseDNS restores the default PTR record of ip.
+func (c *Client) ResetReverseDNS(ctx context.Context, ip string) error {
+	return c.do(ctx, http.MethodDelete, reverseDNSPath(ip), nil, nil)
+}
+
 func (c *Client) ListPlans(ctx context.Context, location string) ([]Plan, error) {
 	var env EnvelopePlans
+	if err := c.do(ctx, http.MethodGet, plansPath(location), nil, &env); err != nil {
+		return nil, err
+	}
+	return env.Data, nil
+}
+
+func plansPath(location string) string {
 	path := "/v1/ordering/plans?showAll=true"
 	if location != "" {
 		path += "&location=" + url.QueryEscape(location)
 	}
-	if err := c.do(ctx, http.MethodGet, path, nil, &env); err != nil {
-		return nil, err
-	}
-	if !env.Success {
-		return nil, fmt.Errorf("%s", env.Message)
-	}
-	return env.Data, nil
+	return path
 }
 
 func (c *Client) CheckRaid(ctx context.Context, raid int, planID int) (bool, error) {
@@ -238,19 +678,44 @@ func (c *Client) CheckRaid(ctx context.Context, raid int, planID int) (bool, err
 	if err := c.do(ctx, http.MethodGet, path, nil, &env); err != nil {
 		return false, err
 	}
-	if !env.Success {
-		return false, fmt.Errorf("%s", env.Message)
-	}
 	return true, nil
 }
 
+const (
+	osPath        = "/v1/ordering/os"
+	locationsPath = "/v1/ordering/locations"
+)
+
 func (c *Client) ListOperatingSystems(ctx context.Context) ([]ServerOS, error) {
 	var env EnvelopeOS
-	if err := c.do(ctx, http.MethodGet, "/v1/ordering/os", nil, &env); err != nil {
+	if err := c.do(ctx, http.MethodGet, osPath, nil, &env); err != nil {
 		return nil, err
 	}
-	if !env.Success {
-		return nil, fmt.Errorf("%s", env.Message)
+	return env.Data, nil
+}
+
+func (c *Client) ListLocations(ctx context.Context) ([]ServerLocation, error) {
+	var env EnvelopeLocations
+	if err := c.do(ctx, http.MethodGet, locationsPath, nil, &env); err != nil {
+		return nil, err
 	}
 	return env.Data, nil
 }
+
+// getConditional is a GET that sends If-None-Match when etag is set. It
+// returns the response's ETag, or notModified when the API answers 304 and
+// out was left untouched.
+func (c *Client) getConditional(ctx context.Context, path, etag string, out any) (newETag string, notModified bool, err error) {
+	var header http.Header
+	if etag != "" {
+		header = http.Header{"If-None-Match": {etag}}
+	}
+	respHeader, err := c.doWithHeaders(ctx, http.MethodGet, path, header, nil, out)
+	if ae, ok := asAPIError(err); ok && ae.Status == http.StatusNotModified && etag != "" {
+		return etag, true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return respHeader.Get("ETag"), false, nil
+}
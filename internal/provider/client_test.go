@@ -3,10 +3,19 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
 )
 
 func TestListOperatingSystems(t *testing.T) {
@@ -210,6 +219,248 @@ func TestGetServer(t *testing.T) {
 	}
 }
 
+func TestUpdateServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/servers/server-123" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPatch {
+			t.Fatalf("expected PATCH, got %s", r.Method)
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(req) != 1 || req["hostname"] != "web-02" {
+			t.Fatalf("unexpected request data: %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    map[string]any{"id": "server-123", "hostname": "web-02"},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123")
+	hostname := "web-02"
+	server, err := c.UpdateServer(context.Background(), "server-123", &UpdateServerRequest{Hostname: &hostname})
+	if err != nil {
+		t.Fatalf("UpdateServer error: %v", err)
+	}
+	if server.Hostname == nil || *server.Hostname != "web-02" {
+		t.Fatalf("expected hostname web-02, got %v", server.Hostname)
+	}
+}
+
+func TestPowerAction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/servers/server-123/power" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req["action"] != PowerActionOff {
+			t.Fatalf("unexpected action: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"success": true})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123")
+	if err := c.PowerAction(context.Background(), "server-123", PowerActionOff); err != nil {
+		t.Fatalf("PowerAction error: %v", err)
+	}
+}
+
+func TestReinstallServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/servers/server-123/reinstall" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		var req ReinstallServerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.OSID != 63 || req.Raid != nil {
+			t.Fatalf("unexpected request data: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"success": true})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123")
+	if err := c.ReinstallServer(context.Background(), "server-123", &ReinstallServerRequest{OSID: 63}); err != nil {
+		t.Fatalf("ReinstallServer error: %v", err)
+	}
+}
+
+func TestFindServerByHostname(t *testing.T) {
+	tests := []struct {
+		name      string
+		hostnames []string
+		wantID    string
+		wantErr   bool
+	}{
+		{
+			name:      "single match",
+			hostnames: []string{"web-01", "web-010"},
+			wantID:    "server-0",
+		},
+		{
+			name:      "no match",
+			hostnames: []string{"web-010"},
+			wantErr:   true,
+		},
+		{
+			name:      "ambiguous",
+			hostnames: []string{"web-01", "web-01"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/servers" || r.URL.Query().Get("hostname") != "web-01" {
+					t.Fatalf("unexpected request: %s", r.URL.String())
+				}
+				data := []map[string]any{}
+				for i, h := range tt.hostnames {
+					data = append(data, map[string]any{"id": fmt.Sprintf("server-%d", i), "hostname": h})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, "k123")
+			s, err := c.FindServerByHostname(context.Background(), "web-01")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.ID != tt.wantID {
+				t.Fatalf("expected server %s, got %s", tt.wantID, s.ID)
+			}
+		})
+	}
+}
+
+func TestListServers(t *testing.T) {
+	tests := []struct {
+		name           string
+		total          int
+		pageSize       int
+		sendTotalCount bool
+		wantRequests   int
+	}{
+		{"single page", 3, 100, true, 1},
+		{"follows totalCount across capped pages", 5, 2, true, 3},
+		{"stops on a short page without totalCount", 150, 100, false, 2},
+		{"stops on an empty page without totalCount", 200, 100, false, 3},
+		{"no servers", 0, 100, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				q := r.URL.Query()
+				if r.URL.Path != "/v1/servers" || q.Get("limit") != "100" || q.Get("hostname") != "" {
+					t.Fatalf("unexpected request: %s", r.URL.String())
+				}
+				page, _ := strconv.Atoi(q.Get("page"))
+				if page != requests {
+					t.Fatalf("expected page %d, got %q", requests, q.Get("page"))
+				}
+				data := []map[string]any{}
+				for i := (page - 1) * tt.pageSize; i < tt.total && i < page*tt.pageSize; i++ {
+					data = append(data, map[string]any{"id": fmt.Sprintf("server-%d", i)})
+				}
+				env := map[string]any{"success": true, "data": data}
+				if tt.sendTotalCount {
+					env["totalCount"] = tt.total
+				}
+				json.NewEncoder(w).Encode(env)
+			}))
+			defer srv.Close()
+
+			servers, err := NewClient(srv.URL, "k123").ListServers(context.Background(), ListServersOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(servers) != tt.total || requests != tt.wantRequests {
+				t.Fatalf("expected %d servers in %d requests, got %d in %d", tt.total, tt.wantRequests, len(servers), requests)
+			}
+			for i, s := range servers {
+				if s.ID != fmt.Sprintf("server-%d", i) {
+					t.Fatalf("unexpected order at %d: %s", i, s.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestListServers_Runaway(t *testing.T) {
+	old := maxServerPages
+	maxServerPages = 5
+	t.Cleanup(func() { maxServerPages = old })
+
+	tests := []struct {
+		name         string
+		distinct     bool
+		wantErr      string
+		wantRequests int
+	}{
+		{"page parameter ignored", false, "repeats page 1", 2},
+		{"never-ending distinct pages", true, "gave up after 5 pages", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				page := 1
+				if tt.distinct {
+					page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+				}
+				data := []map[string]any{}
+				for i := range serverPageSize {
+					data = append(data, map[string]any{"id": fmt.Sprintf("server-%d-%d", page, i)})
+				}
+				json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL, "k123").ListServers(context.Background(), ListServersOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if requests != tt.wantRequests {
+				t.Fatalf("expected %d requests, got %d", tt.wantRequests, requests)
+			}
+		})
+	}
+}
+
 func TestDeleteServer(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/v1/servers/server-123/destroy" {
@@ -290,7 +541,7 @@ func TestCheckRaid(t *testing.T) {
 	}
 }
 
-func TestHTTPError(t *testing.T) {
+func TestAPIError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		w.Write([]byte(`{"success": false, "message": "Server not found"}`))
@@ -303,12 +554,163 @@ func TestHTTPError(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 
-	httpErr, ok := err.(*HTTPError)
+	apiErr, ok := err.(*APIError)
 	if !ok {
-		t.Fatalf("expected HTTPError, got %T", err)
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if apiErr.Status != 404 {
+		t.Fatalf("expected status 404, got %d", apiErr.Status)
 	}
-	if httpErr.Status != 404 {
-		t.Fatalf("expected status 404, got %d", httpErr.Status)
+	if apiErr.Message != "Server not found" {
+		t.Fatalf("expected envelope message to be decoded, got %q", apiErr.Message)
+	}
+	if !IsNotFound(err) {
+		t.Fatal("expected IsNotFound to be true")
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 5 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "GET retried on 503", method: http.MethodGet, status: http.StatusServiceUnavailable, wantCalls: 2},
+		{name: "GET retried on 429", method: http.MethodGet, status: http.StatusTooManyRequests, wantCalls: 2},
+		{name: "POST retried on 429", method: http.MethodPost, status: http.StatusTooManyRequests, wantCalls: 2},
+		{name: "POST not retried on 502", method: http.MethodPost, status: http.StatusBadGateway, wantCalls: 1, wantErr: true},
+		{name: "GET not retried on 400", method: http.MethodGet, status: http.StatusBadRequest, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{"success": true})
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, "k123", WithRetryPolicy(policy))
+			err := c.do(context.Background(), tt.method, "/v1/anything", nil, nil)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error result: %v", err)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("expected %d calls, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestClientRetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: time.Millisecond}))
+	_, err := c.ListOperatingSystems(context.Background())
+
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("expected 4 calls, got %d", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"-1", 0},
+		{"Wed, 01 Jan 2025 12:00:30 GMT", 30 * time.Second},
+		{"Wed, 01 Jan 2025 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinWait: time.Second, MaxWait: 10 * time.Second}
+	for attempt := 0; attempt < 8; attempt++ {
+		d := p.backoff(attempt, 0)
+		if d <= 0 || d > p.MaxWait {
+			t.Fatalf("attempt %d: backoff %s out of bounds", attempt, d)
+		}
+	}
+	if d := p.backoff(0, time.Minute); d != time.Minute {
+		t.Fatalf("expected Retry-After beyond MaxWait to be honoured in full, got %s", d)
+	}
+	if d := p.backoff(0, 3*time.Second); d != 3*time.Second {
+		t.Fatalf("expected Retry-After of 3s to be honoured, got %s", d)
+	}
+}
+
+func TestClientRetryAfterBeyondDeadline(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	err := c.do(ctx, http.MethodGet, "/v1/anything", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "retry after 1m0s") {
+		t.Fatalf("expected an error naming the Retry-After delay, got %v", err)
+	}
+	if ae, ok := asAPIError(err); !ok || ae.Status != http.StatusTooManyRequests {
+		t.Fatalf("expected the 429 to stay inspectable, got %v", err)
+	}
+	if calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Fatalf("expected to give up at once, got %d calls in %s", calls.Load(), time.Since(start))
+	}
+}
+
+func TestClientRetryAfterBeyondLimit(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	// No deadline, like a refresh.
+	c := NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}))
+	start := time.Now()
+	err := c.do(context.Background(), http.MethodGet, "/v1/anything", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "retry after 24h0m0s") {
+		t.Fatalf("expected an error naming the Retry-After delay, got %v", err)
+	}
+	if calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Fatalf("expected to give up at once, got %d calls in %s", calls.Load(), time.Since(start))
 	}
 }
 
@@ -457,3 +859,175 @@ func TestListPlans_Integration_WireSchema(t *testing.T) {
 		t.Fatalf("invalid location: %+v", loc)
 	}
 }
+
+// TestClient_FakeAPI drives the client through a full server lifecycle
+// against the in-memory fake to keep the two wire formats in step.
+func TestClient_FakeAPI(t *testing.T) {
+	old := serverPollInterval
+	serverPollInterval = time.Millisecond
+	defer func() { serverPollInterval = old }()
+
+	srv := httptest.NewServer(fakeapi.New(fakeapi.Options{APIKey: "k123", ProvisionDelay: 20 * time.Millisecond}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "k123")
+	ctx := context.Background()
+
+	raid, hostname := 1, "web-01"
+	created, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, Raid: &raid, Hostname: &hostname})
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	s, err := waitForServerStatus(ctx, c, created.ID, time.Second, isReadyPowerStatus)
+	if err != nil {
+		t.Fatalf("waiting for server: %v", err)
+	}
+	if s.Plan.ID != 10 || s.Location.Keyword != "ny" || s.ServerOS == nil || s.ServerOS.ID != 62 {
+		t.Fatalf("unexpected server: %+v", s)
+	}
+	if price, ok := parseMonthlyPrice(s.MonthlyPrice); !ok || price != 79 {
+		t.Fatalf("expected monthly price 79, got %v", s.MonthlyPrice)
+	}
+
+	found, err := c.FindServerByHostname(ctx, hostname)
+	if err != nil || found.ID != created.ID {
+		t.Fatalf("FindServerByHostname: %v, %+v", err, found)
+	}
+	if err := c.PowerAction(ctx, created.ID, PowerActionOff); err != nil {
+		t.Fatalf("PowerAction: %v", err)
+	}
+	if _, err := waitForServerStatus(ctx, c, created.ID, time.Second, isOffPowerStatus); err != nil {
+		t.Fatalf("waiting for power off: %v", err)
+	}
+	if err := c.DeleteServer(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteServer: %v", err)
+	}
+	if _, err := c.GetServer(ctx, created.ID); !IsNotFound(err) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+
+	_, err = c.CreateServer(ctx, &CreateServerRequest{PlanID: 11, LocationID: 2, OSID: 62})
+	if !IsValidation(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestCreateServerIdempotencyKey(t *testing.T) {
+	api := fakeapi.New(fakeapi.Options{APIKey: "k123"})
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	c := NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: time.Millisecond}))
+	ctx := context.Background()
+
+	// Without a key a POST is not retried after a dropped response.
+	api.DropAllocateResponses(1)
+	if _, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62}); err == nil {
+		t.Fatal("expected an error for the dropped response")
+	}
+	if n := len(api.Servers()); n != 1 {
+		t.Fatalf("expected 1 server, got %d", n)
+	}
+
+	// With a key the retry returns the original order.
+	api.DropAllocateResponses(1)
+	created, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, IdempotencyKey: "order-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(api.Servers()); n != 2 {
+		t.Fatalf("expected 2 servers, got %d", n)
+	}
+	again, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, IdempotencyKey: "order-1"})
+	if err != nil || again.ID != created.ID {
+		t.Fatalf("expected the same server %s for a repeated key, got %v, %v", created.ID, again, err)
+	}
+
+	// The key keeps replaying the order once its server is gone.
+	api.Remove(created.ID)
+	again, err = c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, IdempotencyKey: "order-1"})
+	if err != nil || again.ID != created.ID {
+		t.Fatalf("expected the deleted server %s for a repeated key, got %v, %v", created.ID, again, err)
+	}
+	if n := len(api.Servers()); n != 1 {
+		t.Fatalf("expected 1 server, got %d", n)
+	}
+}
+
+func TestSSHKeys(t *testing.T) {
+	api := fakeapi.New(fakeapi.Options{APIKey: "k123"})
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	c := NewClient(srv.URL, "k123")
+	ctx := context.Background()
+
+	const pub = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGkmoNjYAgrx1g9bEtGLpfsEdCePgSG1zHoZ+Lh4Gk9V ops@example.com"
+	key, err := c.CreateSSHKey(ctx, &CreateSSHKeyRequest{Name: "ops", PublicKey: pub})
+	if err != nil {
+		t.Fatalf("CreateSSHKey: %v", err)
+	}
+	if key.ID == "" || !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+	got, err := c.GetSSHKey(ctx, key.ID)
+	if err != nil || got.PublicKey != pub || got.Name != "ops" {
+		t.Fatalf("GetSSHKey: %v, %+v", err, got)
+	}
+
+	created, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, SSHKeyIDs: []string{key.ID}})
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	if rec, _ := api.Server(created.ID); !slices.Equal(rec.SSHKeyIDs, []string{key.ID}) {
+		t.Fatalf("expected key %s on the server, got %v", key.ID, rec.SSHKeyIDs)
+	}
+	_, err = c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, SSHKeyIDs: []string{"key-missing"}})
+	if ae, ok := asAPIError(err); !ok || len(ae.Fields) != 1 || ae.Fields[0].Field != "sshKeyIds" {
+		t.Fatalf("expected an sshKeyIds field error, got %v", err)
+	}
+
+	if err := c.DeleteSSHKey(ctx, key.ID); err != nil {
+		t.Fatalf("DeleteSSHKey: %v", err)
+	}
+	if _, err := c.GetSSHKey(ctx, key.ID); !IsNotFound(err) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
+
+func TestReverseDNS(t *testing.T) {
+	api := fakeapi.New(fakeapi.Options{APIKey: "k123"})
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	c := NewClient(srv.URL, "k123")
+	ctx := context.Background()
+
+	created, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62})
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	ip := created.IPAddress
+
+	def, err := c.GetReverseDNS(ctx, ip)
+	if err != nil || def.PTR == "" || def.ServerID != created.ID {
+		t.Fatalf("GetReverseDNS: %v, %+v", err, def)
+	}
+	set, err := c.SetReverseDNS(ctx, ip, "mail.example.com")
+	if err != nil || set.PTR != "mail.example.com" {
+		t.Fatalf("SetReverseDNS: %v, %+v", err, set)
+	}
+	if ptr, _ := api.ReverseDNS(ip); ptr != "mail.example.com" {
+		t.Fatalf("expected the API to hold the new PTR, got %q", ptr)
+	}
+	_, err = c.SetReverseDNS(ctx, ip, "not a name")
+	if ae, ok := asAPIError(err); !ok || len(ae.Fields) != 1 || ae.Fields[0].Field != "ptr" {
+		t.Fatalf("expected a ptr field error, got %v", err)
+	}
+
+	if err := c.ResetReverseDNS(ctx, ip); err != nil {
+		t.Fatalf("ResetReverseDNS: %v", err)
+	}
+	if got, _ := c.GetReverseDNS(ctx, ip); got.PTR != def.PTR {
+		t.Fatalf("expected the default PTR %q after reset, got %q", def.PTR, got.PTR)
+	}
+	if _, err := c.GetReverseDNS(ctx, "198.51.100.7"); !IsNotFound(err) {
+		t.Fatalf("expected not found for an address outside the account, got %v", err)
+	}
+}
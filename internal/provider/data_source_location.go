@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type locationDataSource struct {
+	client  *Client
+	catalog *catalog
+}
+
+func NewLocationDataSource() datasource.DataSource { return &locationDataSource{} }
+
+func (d *locationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_location"
+}
+
+func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single Rackdog location. Fails unless exactly one location matches.",
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.Int64Attribute{Optional: true, Computed: true},
+			"name":    schema.StringAttribute{Optional: true, Computed: true},
+			"keyword": schema.StringAttribute{Optional: true, Computed: true},
+			"country": schema.StringAttribute{Optional: true, Computed: true},
+		},
+	}
+}
+
+func (d *locationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+	d.catalog = pd.Catalog
+}
+
+func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config locationItem
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locs, err := d.catalog.ListLocations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list locations", err.Error())
+		return
+	}
+
+	matched := filterLocations(locs, locationFilter{
+		ID:      int(config.ID.ValueInt64()),
+		Name:    config.Name.ValueString(),
+		Keyword: config.Keyword.ValueString(),
+		Country: config.Country.ValueString(),
+	})
+	if len(matched) != 1 {
+		resp.Diagnostics.AddError("Location not found",
+			fmt.Sprintf("Expected exactly one location to match, found %d. Narrow the search with id, keyword, name or country.", len(matched)))
+		return
+	}
+
+	l := matched[0]
+	state := locationItem{
+		ID:      types.Int64Value(int64(l.ID)),
+		Name:    types.StringValue(l.Name),
+		Keyword: types.StringValue(l.Keyword),
+		Country: types.StringValue(l.Country),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type locationsDataSource struct {
+	client  *Client
+	catalog *catalog
+}
+
+func NewLocationsDataSource() datasource.DataSource { return &locationsDataSource{} }
+
+type locationsModel struct {
+	Keyword   types.String   `tfsdk:"keyword"`
+	Country   types.String   `tfsdk:"country"`
+	Locations []locationItem `tfsdk:"locations"`
+}
+
+type locationItem struct {
+	ID      types.Int64  `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Keyword types.String `tfsdk:"keyword"`
+	Country types.String `tfsdk:"country"`
+}
+
+func (d *locationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_locations"
+}
+
+func (d *locationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves Rackdog locations, optionally filtered by keyword or country.",
+		Attributes: map[string]schema.Attribute{
+			"keyword": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the location with this keyword (case-insensitive), e.g. \"ny\".",
+			},
+			"country": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return locations in this country (case-insensitive).",
+			},
+			"locations": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":      schema.Int64Attribute{Computed: true},
+						"name":    schema.StringAttribute{Computed: true},
+						"keyword": schema.StringAttribute{Computed: true},
+						"country": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *locationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+	d.catalog = pd.Catalog
+}
+
+func (d *locationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config locationsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locs, err := d.catalog.ListLocations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list locations", err.Error())
+		return
+	}
+
+	matched := filterLocations(locs, locationFilter{
+		Keyword: config.Keyword.ValueString(),
+		Country: config.Country.ValueString(),
+	})
+
+	state := locationsModel{Keyword: config.Keyword, Country: config.Country, Locations: make([]locationItem, 0, len(matched))}
+	for _, l := range matched {
+		state.Locations = append(state.Locations, locationItem{
+			ID:      types.Int64Value(int64(l.ID)),
+			Name:    types.StringValue(l.Name),
+			Keyword: types.StringValue(l.Keyword),
+			Country: types.StringValue(l.Country),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// locationFilter holds optional location criteria; zero values match all.
+type locationFilter struct {
+	ID      int
+	Name    string
+	Keyword string
+	Country string
+}
+
+func filterLocations(locs []ServerLocation, f locationFilter) []ServerLocation {
+	var out []ServerLocation
+	for _, l := range locs {
+		if f.ID != 0 && l.ID != f.ID {
+			continue
+		}
+		if f.Name != "" && !strings.EqualFold(l.Name, f.Name) {
+			continue
+		}
+		if f.Keyword != "" && !strings.EqualFold(l.Keyword, f.Keyword) {
+			continue
+		}
+		if f.Country != "" && !strings.EqualFold(l.Country, f.Country) {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestLocationsDataSource_Schema(t *testing.T) {
+	ds := NewLocationsDataSource()
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"keyword", "country", "locations"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+		}
+	}
+}
+
+func TestLocationsDataSource_Metadata(t *testing.T) {
+	for _, tt := range []struct {
+		ds   datasource.DataSource
+		want string
+	}{
+		{NewLocationsDataSource(), "rackdog_locations"},
+		{NewLocationDataSource(), "rackdog_location"},
+	} {
+		resp := &datasource.MetadataResponse{}
+		tt.ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "rackdog"}, resp)
+		if resp.TypeName != tt.want {
+			t.Errorf("expected TypeName '%s', got %s", tt.want, resp.TypeName)
+		}
+	}
+}
+
+func TestListLocations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/ordering/locations" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data": []map[string]any{
+				{"id": 1, "name": "New York", "keyword": "ny", "country": "USA"},
+				{"id": 2, "name": "Los Angeles", "keyword": "la", "country": "USA"},
+				{"id": 3, "name": "Amsterdam", "keyword": "ams", "country": "Netherlands"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k123")
+	locs, err := c.ListLocations(context.Background())
+	if err != nil {
+		t.Fatalf("ListLocations error: %v", err)
+	}
+	if len(locs) != 3 {
+		t.Fatalf("expected 3 locations, got %d", len(locs))
+	}
+
+	tests := []struct {
+		name   string
+		filter locationFilter
+		want   []int
+	}{
+		{"no filter", locationFilter{}, []int{1, 2, 3}},
+		{"keyword is case-insensitive", locationFilter{Keyword: "NY"}, []int{1}},
+		{"country", locationFilter{Country: "usa"}, []int{1, 2}},
+		{"id and country", locationFilter{ID: 3, Country: "USA"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterLocations(locs, tt.filter)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, got)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Fatalf("expected %v, got %+v", tt.want, got)
+				}
+			}
+		})
+	}
+}
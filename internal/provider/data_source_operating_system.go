@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type singleOSDataSource struct {
+	client  *Client
+	catalog *catalog
+}
+
+func NewOperatingSystemDataSource() datasource.DataSource { return &singleOSDataSource{} }
+
+type singleOSModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Family    types.String `tfsdk:"family"`
+	Latest    types.Bool   `tfsdk:"latest"`
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Version   types.String `tfsdk:"version"`
+}
+
+func (d *singleOSDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_operating_system"
+}
+
+func (d *singleOSDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Selects a single operating system from Rackdog /ordering/os. " +
+			"Fails when no OS matches, or when several match and latest is not set.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the OS name must match.",
+			},
+			"family": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "OS family parsed from the name, e.g. \"Ubuntu\" for \"Ubuntu 24.04 LTS\". Matched case-insensitively when set.",
+			},
+			"latest": schema.BoolAttribute{
+				Optional: true,
+				Description: "If several operating systems of one family match, pick the one with the highest version. " +
+					"Fails when the matches span several families.",
+			},
+			"id":   schema.Int64Attribute{Computed: true},
+			"name": schema.StringAttribute{Computed: true},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version parsed from the name, e.g. \"24.04\". Empty when the name has no version.",
+			},
+		},
+	}
+}
+
+func (d *singleOSDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+	d.catalog = pd.Catalog
+}
+
+func (d *singleOSDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config singleOSModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	re, err := compileOptional(config.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		return
+	}
+
+	osList, err := d.catalog.ListOperatingSystems(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list operating systems", err.Error())
+		return
+	}
+
+	o, err := selectOS(osList, re, config.Family.ValueString(), config.Latest.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("No unique operating system", err.Error())
+		return
+	}
+
+	family, version := parseOSName(o.Name)
+	state := config
+	state.ID = types.Int64Value(int64(o.ID))
+	state.Name = types.StringValue(o.Name)
+	state.Family = types.StringValue(family)
+	state.Version = types.StringValue(version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+var osVersionRe = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+
+// parseOSName splits a catalog name such as "Ubuntu 24.04 LTS" or
+// "Windows Server 2019" into its family ("Ubuntu", "Windows Server") and
+// version ("24.04", "2019"). The family is everything before the first
+// token that starts with a version number.
+func parseOSName(name string) (family, version string) {
+	fields := strings.Fields(name)
+	for i, f := range fields {
+		if m := osVersionRe.FindStringSubmatch(f); m != nil {
+			return strings.Join(fields[:i], " "), m[1]
+		}
+	}
+	return strings.Join(fields, " "), ""
+}
+
+// compareVersions compares dotted numeric versions segment by segment;
+// "24.04" > "22.04" > "22" and an empty version sorts first.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return len(as) - len(bs)
+}
+
+func selectOS(osList []ServerOS, nameRe *regexp.Regexp, family string, latest bool) (ServerOS, error) {
+	var matched []ServerOS
+	for _, o := range osList {
+		if nameRe != nil && !nameRe.MatchString(o.Name) {
+			continue
+		}
+		if f, _ := parseOSName(o.Name); family != "" && !strings.EqualFold(f, family) {
+			continue
+		}
+		matched = append(matched, o)
+	}
+
+	switch {
+	case len(matched) == 0:
+		return ServerOS{}, fmt.Errorf("no operating system matches the given filters")
+	case len(matched) == 1:
+		return matched[0], nil
+	case latest:
+		// Versions only compare within a family: Debian 12 is not newer
+		// than Ubuntu 24.04.
+		var families []string
+		for _, o := range matched {
+			f, _ := parseOSName(o.Name)
+			if !slices.ContainsFunc(families, func(g string) bool { return strings.EqualFold(f, g) }) {
+				families = append(families, f)
+			}
+		}
+		if len(families) > 1 {
+			return ServerOS{}, fmt.Errorf("latest picks the highest version within one family, but the matches span %d: %s. Set family or narrow name_regex",
+				len(families), quoteJoin(families))
+		}
+		return slices.MaxFunc(matched, func(a, b ServerOS) int {
+			_, va := parseOSName(a.Name)
+			_, vb := parseOSName(b.Name)
+			if c := compareVersions(va, vb); c != 0 {
+				return c
+			}
+			return a.ID - b.ID
+		}), nil
+	}
+
+	names := make([]string, len(matched))
+	for i, o := range matched {
+		names[i] = fmt.Sprintf("%s (id %d)", o.Name, o.ID)
+	}
+	return ServerOS{}, fmt.Errorf("%d operating systems match: %s. Narrow name_regex or family, or set latest = true",
+		len(matched), strings.Join(names, ", "))
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestSingleOSDataSource_Metadata(t *testing.T) {
+	resp := &datasource.MetadataResponse{}
+	NewOperatingSystemDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "rackdog"}, resp)
+	if resp.TypeName != "rackdog_operating_system" {
+		t.Errorf("expected TypeName 'rackdog_operating_system', got %s", resp.TypeName)
+	}
+}
+
+func TestParseOSName(t *testing.T) {
+	tests := []struct {
+		name, family, version string
+	}{
+		{"Ubuntu 24.04 LTS", "Ubuntu", "24.04"},
+		{"Debian 12", "Debian", "12"},
+		{"Windows Server 2019", "Windows Server", "2019"},
+		{"Rocky Linux 9.4", "Rocky Linux", "9.4"},
+		{"Proxmox VE v8.2", "Proxmox VE", "8.2"},
+		{"Custom ISO", "Custom ISO", ""},
+	}
+	for _, tt := range tests {
+		family, version := parseOSName(tt.name)
+		if family != tt.family || version != tt.version {
+			t.Errorf("parseOSName(%q) = (%q, %q), want (%q, %q)", tt.name, family, version, tt.family, tt.version)
+		}
+	}
+}
+
+func TestSelectOS(t *testing.T) {
+	catalog := []ServerOS{
+		{ID: 48, Name: "Ubuntu 22.04 LTS"},
+		{ID: 62, Name: "Ubuntu 24.04 LTS"},
+		{ID: 50, Name: "Ubuntu 20.04 LTS"},
+		{ID: 63, Name: "Debian 12"},
+		{ID: 55, Name: "Windows Server 2019"},
+	}
+
+	tests := []struct {
+		name    string
+		re      *regexp.Regexp
+		family  string
+		latest  bool
+		wantID  int
+		wantErr bool
+	}{
+		{name: "regex", re: regexp.MustCompile(`^Debian`), wantID: 63},
+		{name: "family ambiguous", family: "ubuntu", wantErr: true},
+		{name: "family latest", family: "ubuntu", latest: true, wantID: 62},
+		{name: "regex and family", re: regexp.MustCompile(`22\.04`), family: "Ubuntu", wantID: 48},
+		{name: "no match", family: "Alpine", latest: true, wantErr: true},
+		{name: "regex latest", re: regexp.MustCompile(`^Ubuntu`), latest: true, wantID: 62},
+		{name: "latest across families", re: regexp.MustCompile(`Ubuntu|Debian`), latest: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := selectOS(catalog, tt.re, tt.family, tt.latest)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", o)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if o.ID != tt.wantID {
+				t.Fatalf("expected OS %d, got %d", tt.wantID, o.ID)
+			}
+		})
+	}
+}
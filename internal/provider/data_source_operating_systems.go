@@ -8,7 +8,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type osDataSource struct{ client *Client }
+type osDataSource struct {
+	client  *Client
+	catalog *catalog
+}
 
 func NewOperatingSystemsDataSource() datasource.DataSource { return &osDataSource{} }
 
@@ -48,6 +51,7 @@ func (d *osDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 	}
 	pd := req.ProviderData.(*ProviderData)
 	d.client = pd.Client
+	d.catalog = pd.Catalog
 }
 
 func (d *osDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -56,7 +60,7 @@ func (d *osDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp
 		return
 	}
 
-	osList, err := d.client.ListOperatingSystems(ctx)
+	osList, err := d.catalog.ListOperatingSystems(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to list operating systems", err.Error())
 		return
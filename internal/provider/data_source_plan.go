@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Tie-breaking strategies for rackdog_plan.
+const (
+	planSelectionMostRecent = "most_recent"
+	planSelectionCheapest   = "cheapest"
+)
+
+type planDataSource struct {
+	client  *Client
+	catalog *catalog
+}
+
+func NewPlanDataSource() datasource.DataSource { return &planDataSource{} }
+
+type planModel struct {
+	Name            types.String       `tfsdk:"name"`
+	NameRegex       types.String       `tfsdk:"name_regex"`
+	MinRAM          types.Int64        `tfsdk:"min_ram"`
+	MinCores        types.Int64        `tfsdk:"min_cores"`
+	MinStorage      types.Int64        `tfsdk:"min_storage"`
+	CPUNameRegex    types.String       `tfsdk:"cpu_name_regex"`
+	Location        types.String       `tfsdk:"location"`
+	MaxMonthlyPrice types.Float64      `tfsdk:"max_monthly_price"`
+	Selection       types.String       `tfsdk:"selection"`
+	ID              types.Int64        `tfsdk:"id"`
+	RAMGB           types.Int64        `tfsdk:"ram"`
+	Storage         types.Int64        `tfsdk:"storage"`
+	CPUName         types.String       `tfsdk:"cpu_name"`
+	Cores           types.Int64        `tfsdk:"cores"`
+	CPUSpeedGHz     types.Float64      `tfsdk:"cpu_speed_ghz"`
+	MonthlyPrice    types.Float64      `tfsdk:"monthly_price"`
+	Locations       []planLocationItem `tfsdk:"locations"`
+}
+
+func (d *planDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plan"
+}
+
+func (d *planDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Selects a single hardware plan by name or hardware and price filters. " +
+			"Fails when no plan matches, or when several match and no selection strategy is set.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact plan name.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the plan name must match.",
+			},
+			"min_ram": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum RAM in GB.",
+			},
+			"min_cores": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of CPU cores.",
+			},
+			"min_storage": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum storage in GB.",
+			},
+			"cpu_name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the CPU name must match.",
+			},
+			"location": schema.StringAttribute{
+				Optional:    true,
+				Description: "Location keyword the plan must be sold in. Prices are taken from this location.",
+			},
+			"max_monthly_price": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum monthly price.",
+			},
+			"selection": schema.StringAttribute{
+				Optional:    true,
+				Description: "How to pick between several matching plans: \"cheapest\" or \"most_recent\".",
+				Validators:  []validator.String{stringOneOf(planSelectionCheapest, planSelectionMostRecent)},
+			},
+			"id":            schema.Int64Attribute{Computed: true},
+			"ram":           schema.Int64Attribute{Computed: true},
+			"storage":       schema.Int64Attribute{Computed: true},
+			"cpu_name":      schema.StringAttribute{Computed: true},
+			"cores":         schema.Int64Attribute{Computed: true},
+			"cpu_speed_ghz": schema.Float64Attribute{Computed: true},
+			"monthly_price": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Monthly price at `location`, or the lowest price across locations when unset.",
+			},
+			"locations": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":            schema.Int64Attribute{Computed: true},
+						"keyword":       schema.StringAttribute{Computed: true},
+						"name":          schema.StringAttribute{Computed: true},
+						"monthly_price": schema.Float64Attribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *planDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+	d.catalog = pd.Catalog
+}
+
+func (d *planDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config planModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	f := planFilter{
+		Name:            config.Name.ValueString(),
+		MinRAM:          int(config.MinRAM.ValueInt64()),
+		MinCores:        int(config.MinCores.ValueInt64()),
+		MinStorage:      int(config.MinStorage.ValueInt64()),
+		Location:        config.Location.ValueString(),
+		MaxMonthlyPrice: config.MaxMonthlyPrice.ValueFloat64(),
+	}
+	var err error
+	if f.NameRegex, err = compileOptional(config.NameRegex); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		return
+	}
+	if f.CPUNameRegex, err = compileOptional(config.CPUNameRegex); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cpu_name_regex"), "Invalid regular expression", err.Error())
+		return
+	}
+
+	plans, err := d.catalog.ListPlans(ctx, f.Location)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list plans", err.Error())
+		return
+	}
+
+	p, err := selectPlan(filterPlans(plans, f), f.Location, config.Selection.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("No unique plan", err.Error())
+		return
+	}
+
+	item := newPlanItem(p)
+	state := config
+	state.ID = item.ID
+	state.Name = item.Name
+	state.RAMGB = item.RAMGB
+	state.Storage = item.Storage
+	state.CPUName = item.CPUName
+	state.Cores = item.Cores
+	state.CPUSpeedGHz = item.CPUSpeedGHz
+	state.Locations = item.Locations
+	state.MonthlyPrice = types.Float64Null()
+	if price, ok := planPrice(p, f.Location); ok {
+		state.MonthlyPrice = types.Float64Value(price)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// planFilter holds optional plan criteria; zero values match all.
+type planFilter struct {
+	Name            string
+	NameRegex       *regexp.Regexp
+	MinRAM          int
+	MinCores        int
+	MinStorage      int
+	CPUNameRegex    *regexp.Regexp
+	Location        string
+	MaxMonthlyPrice float64
+}
+
+func filterPlans(plans []Plan, f planFilter) []Plan {
+	var out []Plan
+	for _, p := range plans {
+		if f.Name != "" && p.Name != f.Name {
+			continue
+		}
+		if f.NameRegex != nil && !f.NameRegex.MatchString(p.Name) {
+			continue
+		}
+		if p.RAMGB < f.MinRAM || p.CPU.Cores < f.MinCores || p.Storage < f.MinStorage {
+			continue
+		}
+		if f.CPUNameRegex != nil && !f.CPUNameRegex.MatchString(p.CPU.Name) {
+			continue
+		}
+		price, sold := planPrice(p, f.Location)
+		if f.Location != "" && !sold {
+			continue
+		}
+		if f.MaxMonthlyPrice > 0 && (!sold || price > f.MaxMonthlyPrice) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// planPrice returns the monthly price of p at the location with the given
+// keyword, or the lowest price across its locations when keyword is empty.
+// ok is false when the plan is not sold there (or anywhere).
+func planPrice(p Plan, keyword string) (price float64, ok bool) {
+	price = math.Inf(1)
+	for _, l := range p.Locations {
+		if keyword != "" && !strings.EqualFold(l.Keyword, keyword) {
+			continue
+		}
+		price = min(price, l.MonthlyPrice)
+		ok = true
+	}
+	return price, ok
+}
+
+// selectPlan returns the only plan in plans, or breaks a tie with the
+// given selection strategy.
+func selectPlan(plans []Plan, location, selection string) (Plan, error) {
+	switch {
+	case len(plans) == 0:
+		return Plan{}, fmt.Errorf("no plan matches the given filters")
+	case len(plans) == 1:
+		return plans[0], nil
+	}
+
+	switch selection {
+	case planSelectionCheapest:
+		return slices.MinFunc(plans, func(a, b Plan) int {
+			pa, _ := planPrice(a, location)
+			pb, _ := planPrice(b, location)
+			if c := cmp.Compare(pa, pb); c != 0 {
+				return c
+			}
+			return a.ID - b.ID
+		}), nil
+	case planSelectionMostRecent:
+		return slices.MaxFunc(plans, func(a, b Plan) int {
+			ta, errA := time.Parse(time.RFC3339, a.CreatedAt)
+			tb, errB := time.Parse(time.RFC3339, b.CreatedAt)
+			if errA == nil && errB == nil && !ta.Equal(tb) {
+				return ta.Compare(tb)
+			}
+			return a.ID - b.ID
+		}), nil
+	}
+
+	names := make([]string, len(plans))
+	for i, p := range plans {
+		names[i] = fmt.Sprintf("%s (id %d)", p.Name, p.ID)
+	}
+	return Plan{}, fmt.Errorf("%d plans match: %s. Narrow the filters or set selection to %q or %q",
+		len(plans), strings.Join(names, ", "), planSelectionCheapest, planSelectionMostRecent)
+}
+
+func compileOptional(v types.String) (*regexp.Regexp, error) {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return nil, nil
+	}
+	return regexp.Compile(v.ValueString())
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+var testPlans = []Plan{
+	{
+		ID: 8, Name: "test", CPU: CPU{Name: "Intel Xeon E3", Cores: 4, Speed: 3.5}, RAMGB: 16, Storage: 500,
+		Locations: []PlanLocation{{ID: 1, Keyword: "ny", MonthlyPrice: 79}, {ID: 2, Keyword: "la", MonthlyPrice: 69}},
+		CreatedAt: "2024-01-01T00:00:00Z",
+	},
+	{
+		ID: 10, Name: "standard", CPU: CPU{Name: "AMD EPYC 7302", Cores: 16, Speed: 3.0}, RAMGB: 64, Storage: 1000,
+		Locations: []PlanLocation{{ID: 1, Keyword: "ny", MonthlyPrice: 149}},
+		CreatedAt: "2024-06-01T00:00:00Z",
+	},
+	{
+		ID: 12, Name: "standard-plus", CPU: CPU{Name: "AMD EPYC 7402", Cores: 24, Speed: 2.8}, RAMGB: 128, Storage: 2000,
+		Locations: []PlanLocation{{ID: 1, Keyword: "ny", MonthlyPrice: 229}, {ID: 2, Keyword: "la", MonthlyPrice: 219}},
+		CreatedAt: "2025-01-01T00:00:00Z",
+	},
+}
+
+func TestPlanDataSource_Metadata(t *testing.T) {
+	resp := &datasource.MetadataResponse{}
+	NewPlanDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "rackdog"}, resp)
+	if resp.TypeName != "rackdog_plan" {
+		t.Errorf("expected TypeName 'rackdog_plan', got %s", resp.TypeName)
+	}
+}
+
+func TestSelectPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    planFilter
+		selection string
+		wantID    int
+		wantErr   bool
+	}{
+		{name: "by name", filter: planFilter{Name: "test"}, wantID: 8},
+		{name: "by cpu regex", filter: planFilter{CPUNameRegex: regexp.MustCompile(`^Intel`)}, wantID: 8},
+		{name: "hardware minimums", filter: planFilter{MinRAM: 64, MinCores: 20}, wantID: 12},
+		{name: "location", filter: planFilter{Location: "LA", MinStorage: 1000}, wantID: 12},
+		{name: "price cap", filter: planFilter{MaxMonthlyPrice: 150, MinCores: 8}, wantID: 10},
+		{name: "ambiguous", filter: planFilter{NameRegex: regexp.MustCompile(`^standard`)}, wantErr: true},
+		{name: "cheapest", filter: planFilter{Location: "ny"}, selection: planSelectionCheapest, wantID: 8},
+		{name: "most recent", filter: planFilter{Location: "ny"}, selection: planSelectionMostRecent, wantID: 12},
+		{name: "no match", filter: planFilter{MinRAM: 512}, selection: planSelectionCheapest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := selectPlan(filterPlans(testPlans, tt.filter), tt.filter.Location, tt.selection)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got plan %d", p.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.ID != tt.wantID {
+				t.Fatalf("expected plan %d, got %d", tt.wantID, p.ID)
+			}
+		})
+	}
+}
+
+func TestPlanPrice(t *testing.T) {
+	if price, ok := planPrice(testPlans[0], ""); !ok || price != 69 {
+		t.Errorf("expected lowest price 69, got %v (ok=%v)", price, ok)
+	}
+	if price, ok := planPrice(testPlans[0], "NY"); !ok || price != 79 {
+		t.Errorf("expected ny price 79, got %v (ok=%v)", price, ok)
+	}
+	if _, ok := planPrice(testPlans[1], "la"); ok {
+		t.Error("expected plan 10 not to be sold in la")
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type plansDataSource struct{ client *Client }
+type plansDataSource struct {
+	client  *Client
+	catalog *catalog
+}
 
 func NewPlansDataSource() datasource.DataSource { return &plansDataSource{} }
 
@@ -18,12 +21,21 @@ type plansModel struct {
 }
 
 type planItem struct {
-	ID      types.Int64  `tfsdk:"id"`
-	Name    types.String `tfsdk:"name"`
-	RAMGB   types.Int64  `tfsdk:"ram"`
-	Storage types.Int64  `tfsdk:"storage"`
-	CPUName types.String `tfsdk:"cpu_name"`
-	Cores   types.Int64  `tfsdk:"cores"`
+	ID          types.Int64        `tfsdk:"id"`
+	Name        types.String       `tfsdk:"name"`
+	RAMGB       types.Int64        `tfsdk:"ram"`
+	Storage     types.Int64        `tfsdk:"storage"`
+	CPUName     types.String       `tfsdk:"cpu_name"`
+	Cores       types.Int64        `tfsdk:"cores"`
+	CPUSpeedGHz types.Float64      `tfsdk:"cpu_speed_ghz"`
+	Locations   []planLocationItem `tfsdk:"locations"`
+}
+
+type planLocationItem struct {
+	ID           types.Int64   `tfsdk:"id"`
+	Keyword      types.String  `tfsdk:"keyword"`
+	Name         types.String  `tfsdk:"name"`
+	MonthlyPrice types.Float64 `tfsdk:"monthly_price"`
 }
 
 func (d *plansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,12 +51,25 @@ func (d *plansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":       schema.Int64Attribute{Computed: true},
-						"name":     schema.StringAttribute{Computed: true},
-						"ram":      schema.Int64Attribute{Computed: true},
-						"storage":  schema.Int64Attribute{Computed: true},
-						"cpu_name": schema.StringAttribute{Computed: true},
-						"cores":    schema.Int64Attribute{Computed: true},
+						"id":            schema.Int64Attribute{Computed: true},
+						"name":          schema.StringAttribute{Computed: true},
+						"ram":           schema.Int64Attribute{Computed: true},
+						"storage":       schema.Int64Attribute{Computed: true},
+						"cpu_name":      schema.StringAttribute{Computed: true},
+						"cores":         schema.Int64Attribute{Computed: true},
+						"cpu_speed_ghz": schema.Float64Attribute{Computed: true},
+						"locations": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Locations the plan is sold in, with the monthly price at each.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id":            schema.Int64Attribute{Computed: true},
+									"keyword":       schema.StringAttribute{Computed: true},
+									"name":          schema.StringAttribute{Computed: true},
+									"monthly_price": schema.Float64Attribute{Computed: true},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -58,6 +83,7 @@ func (d *plansDataSource) Configure(_ context.Context, req datasource.ConfigureR
 	}
 	pd := req.ProviderData.(*ProviderData)
 	d.client = pd.Client
+	d.catalog = pd.Catalog
 }
 
 func (d *plansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -77,7 +103,7 @@ func (d *plansDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		loc = config.Location.ValueString()
 	}
 
-	plans, err := d.client.ListPlans(ctx, loc)
+	plans, err := d.catalog.ListPlans(ctx, loc)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to list plans", err.Error())
 		return
@@ -85,15 +111,30 @@ func (d *plansDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	state := plansModel{Location: config.Location, Plans: make([]planItem, 0, len(plans))}
 	for _, p := range plans {
-		state.Plans = append(state.Plans, planItem{
-			ID:      types.Int64Value(int64(p.ID)),
-			Name:    types.StringValue(p.Name),
-			RAMGB:   types.Int64Value(int64(p.RAMGB)),
-			Storage: types.Int64Value(int64(p.Storage)),
-			CPUName: types.StringValue(p.CPU.Name),
-			Cores:   types.Int64Value(int64(p.CPU.Cores)),
-		})
+		state.Plans = append(state.Plans, newPlanItem(p))
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
+
+func newPlanItem(p Plan) planItem {
+	item := planItem{
+		ID:          types.Int64Value(int64(p.ID)),
+		Name:        types.StringValue(p.Name),
+		RAMGB:       types.Int64Value(int64(p.RAMGB)),
+		Storage:     types.Int64Value(int64(p.Storage)),
+		CPUName:     types.StringValue(p.CPU.Name),
+		Cores:       types.Int64Value(int64(p.CPU.Cores)),
+		CPUSpeedGHz: types.Float64Value(p.CPU.Speed),
+		Locations:   make([]planLocationItem, 0, len(p.Locations)),
+	}
+	for _, l := range p.Locations {
+		item.Locations = append(item.Locations, planLocationItem{
+			ID:           types.Int64Value(int64(l.ID)),
+			Keyword:      types.StringValue(l.Keyword),
+			Name:         types.StringValue(l.Name),
+			MonthlyPrice: types.Float64Value(l.MonthlyPrice),
+		})
+	}
+	return item
+}
@@ -91,3 +91,27 @@ func TestPlansDataSource_Metadata(t *testing.T) {
 		t.Errorf("expected TypeName 'rackdog_plans', got %s", resp.TypeName)
 	}
 }
+
+func TestNewPlanItem(t *testing.T) {
+	item := newPlanItem(Plan{
+		ID:   10,
+		Name: "Standard Plan",
+		CPU:  CPU{Name: "Intel Xeon E5", Cores: 16, Speed: 2.4},
+		Locations: []PlanLocation{
+			{ID: 1, Name: "New York", Keyword: "NY", MonthlyPrice: 149.5},
+			{ID: 2, Name: "Los Angeles", Keyword: "LA", MonthlyPrice: 139},
+		},
+		RAMGB:   32,
+		Storage: 200,
+	})
+
+	if item.CPUSpeedGHz.ValueFloat64() != 2.4 {
+		t.Errorf("expected cpu_speed_ghz 2.4, got %v", item.CPUSpeedGHz)
+	}
+	if len(item.Locations) != 2 {
+		t.Fatalf("expected 2 locations, got %d", len(item.Locations))
+	}
+	if item.Locations[0].Keyword.ValueString() != "NY" || item.Locations[0].MonthlyPrice.ValueFloat64() != 149.5 {
+		t.Errorf("unexpected first location: %+v", item.Locations[0])
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type serverDataSource struct{ client *Client }
+
+var _ datasource.DataSourceWithValidateConfig = &serverDataSource{}
+
+func NewServerDataSource() datasource.DataSource { return &serverDataSource{} }
+
+type serverDataModel struct {
+	ID           types.String     `tfsdk:"id"`
+	Hostname     types.String     `tfsdk:"hostname"`
+	IPAddress    types.String     `tfsdk:"ip_address"`
+	Status       types.String     `tfsdk:"status"`
+	PowerState   types.String     `tfsdk:"power_state"`
+	Raid         types.Int64      `tfsdk:"raid"`
+	MonthlyPrice types.Float64    `tfsdk:"monthly_price"`
+	Plan         *serverPlanModel `tfsdk:"plan"`
+	Location     *locationItem    `tfsdk:"location"`
+	OS           *osItem          `tfsdk:"os"`
+	Tags         types.Map        `tfsdk:"tags"`
+}
+
+type serverPlanModel struct {
+	ID      types.Int64  `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	RAMGB   types.Int64  `tfsdk:"ram"`
+	Storage types.Int64  `tfsdk:"storage"`
+	CPUName types.String `tfsdk:"cpu_name"`
+	Cores   types.Int64  `tfsdk:"cores"`
+}
+
+func (d *serverDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (d *serverDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := serverDetailAttributes()
+	attrs["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Server ID. Exactly one of id or hostname must be set.",
+	}
+	attrs["hostname"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Exact hostname. Fails unless exactly one server has it.",
+	}
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing server by ID or hostname, including servers not managed by Terraform.",
+		Attributes:  attrs,
+	}
+}
+
+// serverDetailAttributes returns the computed server attributes shared by
+// rackdog_server and the items of rackdog_servers.
+func serverDetailAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"ip_address": schema.StringAttribute{Computed: true},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "Power status reported by the API, e.g. \"ON\" or \"PROVISIONING\".",
+		},
+		"power_state": schema.StringAttribute{
+			Computed:    true,
+			Description: "\"on\" or \"off\"; null while the server is in a transitional status.",
+		},
+		"raid":          schema.Int64Attribute{Computed: true},
+		"monthly_price": schema.Float64Attribute{Computed: true},
+		"tags":          schema.MapAttribute{Computed: true, ElementType: types.StringType},
+		"plan": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"id":       schema.Int64Attribute{Computed: true},
+				"name":     schema.StringAttribute{Computed: true},
+				"ram":      schema.Int64Attribute{Computed: true},
+				"storage":  schema.Int64Attribute{Computed: true},
+				"cpu_name": schema.StringAttribute{Computed: true},
+				"cores":    schema.Int64Attribute{Computed: true},
+			},
+		},
+		"location": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"id":      schema.Int64Attribute{Computed: true},
+				"name":    schema.StringAttribute{Computed: true},
+				"keyword": schema.StringAttribute{Computed: true},
+				"country": schema.StringAttribute{Computed: true},
+			},
+		},
+		"os": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"id":   schema.Int64Attribute{Computed: true},
+				"name": schema.StringAttribute{Computed: true},
+			},
+		},
+	}
+}
+
+func (d *serverDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+}
+
+func (d *serverDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config serverDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ID.IsUnknown() || config.Hostname.IsUnknown() {
+		return
+	}
+	if config.ID.IsNull() == config.Hostname.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid server lookup", "Exactly one of id or hostname must be set.")
+	}
+}
+
+func (d *serverDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config serverDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.ID.ValueString()
+	if !config.Hostname.IsNull() {
+		found, err := d.client.FindServerByHostname(ctx, config.Hostname.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("hostname"), "Server not found", err.Error())
+			return
+		}
+		id = found.ID
+	}
+
+	s, err := d.client.GetServer(ctx, id)
+	if err != nil {
+		if IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "Server not found", "No server with ID "+id+".")
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read server", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newServerDataModel(s))...)
+}
+
+func newServerDataModel(s *Server) *serverDataModel {
+	m := &serverDataModel{
+		ID:           types.StringValue(s.ID),
+		Hostname:     types.StringPointerValue(s.Hostname),
+		IPAddress:    types.StringValue(s.IPAddress),
+		Status:       types.StringValue(powerStatus(s)),
+		PowerState:   types.StringNull(),
+		Raid:         types.Int64Null(),
+		MonthlyPrice: types.Float64Null(),
+		Tags:         tagsValue(s.Tags),
+		Plan: &serverPlanModel{
+			ID:      types.Int64Value(int64(s.Plan.ID)),
+			Name:    types.StringValue(s.Plan.Name),
+			RAMGB:   types.Int64Value(int64(s.Plan.RAMGB)),
+			Storage: types.Int64Value(int64(s.Plan.Storage)),
+			CPUName: types.StringValue(s.Plan.CPUName),
+			Cores:   types.Int64Value(int64(s.Plan.Cores)),
+		},
+		Location: &locationItem{
+			ID:      types.Int64Value(int64(s.Location.ID)),
+			Name:    types.StringValue(s.Location.Name),
+			Keyword: types.StringValue(s.Location.Keyword),
+			Country: types.StringValue(s.Location.Country),
+		},
+	}
+	if ps := powerStateOf(powerStatus(s)); ps != "" {
+		m.PowerState = types.StringValue(ps)
+	}
+	if s.Raid != nil {
+		m.Raid = types.Int64Value(int64(*s.Raid))
+	}
+	if price, ok := parseMonthlyPrice(s.MonthlyPrice); ok {
+		m.MonthlyPrice = types.Float64Value(price)
+	}
+	if s.ServerOS != nil {
+		m.OS = &osItem{
+			ID:   types.Int64Value(int64(s.ServerOS.ID)),
+			Name: types.StringValue(s.ServerOS.Name),
+		}
+	}
+	return m
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
+)
+
+func TestServerDataSource_Schema(t *testing.T) {
+	ds := NewServerDataSource()
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "hostname", "ip_address", "status", "power_state", "plan", "location", "os", "monthly_price"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+		}
+	}
+
+	mresp := &datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "rackdog"}, mresp)
+	if mresp.TypeName != "rackdog_server" {
+		t.Errorf("expected TypeName 'rackdog_server', got %s", mresp.TypeName)
+	}
+}
+
+func TestNewServerDataModel(t *testing.T) {
+	srv := httptest.NewServer(fakeapi.New(fakeapi.Options{}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "k123")
+	ctx := context.Background()
+
+	hostname := "db-01"
+	created, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 12, LocationID: 2, OSID: 70, Hostname: &hostname})
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	s, err := c.GetServer(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetServer: %v", err)
+	}
+
+	m := newServerDataModel(s)
+	if m.ID.ValueString() != created.ID || m.Hostname.ValueString() != hostname || m.IPAddress.ValueString() == "" {
+		t.Fatalf("unexpected identity: %+v", m)
+	}
+	if m.Status.ValueString() != fakeapi.StatusOn || m.PowerState.ValueString() != PowerActionOn {
+		t.Fatalf("unexpected power: %s / %s", m.Status, m.PowerState)
+	}
+	if m.Plan.Name.ValueString() != "c1.large" || m.Plan.Cores.ValueInt64() != 12 || m.Location.Keyword.ValueString() != "la" {
+		t.Fatalf("unexpected plan or location: %+v %+v", m.Plan, m.Location)
+	}
+	if m.OS == nil || m.OS.ID.ValueInt64() != 70 || m.MonthlyPrice.ValueFloat64() != 219 {
+		t.Fatalf("unexpected os or price: %+v %s", m.OS, m.MonthlyPrice)
+	}
+	if !m.Raid.IsNull() {
+		t.Fatalf("expected null raid, got %s", m.Raid)
+	}
+}
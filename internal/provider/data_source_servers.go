@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type serversDataSource struct{ client *Client }
+
+func NewServersDataSource() datasource.DataSource { return &serversDataSource{} }
+
+type serversModel struct {
+	HostnamePrefix types.String      `tfsdk:"hostname_prefix"`
+	HostnameRegex  types.String      `tfsdk:"hostname_regex"`
+	LocationID     types.Int64       `tfsdk:"location_id"`
+	PlanID         types.Int64       `tfsdk:"plan_id"`
+	OSID           types.Int64       `tfsdk:"os_id"`
+	Status         types.String      `tfsdk:"status"`
+	Tags           map[string]string `tfsdk:"tags"`
+	IDs            []types.String    `tfsdk:"ids"`
+	Servers        []serverDataModel `tfsdk:"servers"`
+}
+
+func (d *serversDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_servers"
+}
+
+func (d *serversDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	item := serverDetailAttributes()
+	item["id"] = schema.StringAttribute{Computed: true}
+	item["hostname"] = schema.StringAttribute{Computed: true}
+
+	resp.Schema = schema.Schema{
+		Description: "Lists the servers in the account. All filters are optional and combine with AND.",
+		Attributes: map[string]schema.Attribute{
+			"hostname_prefix": schema.StringAttribute{Optional: true},
+			"hostname_regex":  schema.StringAttribute{Optional: true, Description: "RE2 regular expression matched against the hostname."},
+			"location_id":     schema.Int64Attribute{Optional: true},
+			"plan_id":         schema.Int64Attribute{Optional: true},
+			"os_id":           schema.Int64Attribute{Optional: true},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Power status to match, case-insensitively, e.g. \"ON\" or \"OFF\".",
+			},
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags the server must have, with exactly these values.",
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "IDs of the matching servers, in the order the API returned them.",
+			},
+			"servers": schema.ListNestedAttribute{
+				Computed:     true,
+				NestedObject: schema.NestedAttributeObject{Attributes: item},
+			},
+		},
+	}
+}
+
+func (d *serversDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	d.client = pd.Client
+}
+
+func (d *serversDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var config serversModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	f := serverFilter{
+		HostnamePrefix: config.HostnamePrefix.ValueString(),
+		LocationID:     int(config.LocationID.ValueInt64()),
+		PlanID:         int(config.PlanID.ValueInt64()),
+		OSID:           int(config.OSID.ValueInt64()),
+		Status:         config.Status.ValueString(),
+		Tags:           config.Tags,
+	}
+	var err error
+	if f.HostnameRegex, err = compileOptional(config.HostnameRegex); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("hostname_regex"), "Invalid regular expression", err.Error())
+		return
+	}
+
+	servers, err := d.client.ListServers(ctx, ListServersOptions{})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list servers", err.Error())
+		return
+	}
+
+	matched := filterServers(servers, f)
+	config.IDs = make([]types.String, 0, len(matched))
+	config.Servers = make([]serverDataModel, 0, len(matched))
+	for i := range matched {
+		config.IDs = append(config.IDs, types.StringValue(matched[i].ID))
+		config.Servers = append(config.Servers, *newServerDataModel(&matched[i]))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// serverFilter holds optional server criteria; zero values match all.
+type serverFilter struct {
+	HostnamePrefix string
+	HostnameRegex  *regexp.Regexp
+	LocationID     int
+	PlanID         int
+	OSID           int
+	Status         string
+	Tags           map[string]string
+}
+
+func filterServers(servers []Server, f serverFilter) []Server {
+	var out []Server
+	for _, s := range servers {
+		var hostname string
+		if s.Hostname != nil {
+			hostname = *s.Hostname
+		}
+		if f.HostnamePrefix != "" && !strings.HasPrefix(hostname, f.HostnamePrefix) {
+			continue
+		}
+		if f.HostnameRegex != nil && !f.HostnameRegex.MatchString(hostname) {
+			continue
+		}
+		if f.LocationID != 0 && s.Location.ID != f.LocationID {
+			continue
+		}
+		if f.PlanID != 0 && s.Plan.ID != f.PlanID {
+			continue
+		}
+		if f.OSID != 0 && (s.ServerOS == nil || s.ServerOS.ID != f.OSID) {
+			continue
+		}
+		if f.Status != "" && !strings.EqualFold(powerStatus(&s), f.Status) {
+			continue
+		}
+		if !hasTags(s.Tags, f.Tags) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// hasTags reports whether tags contains every key in want with the same
+// value.
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := tags[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
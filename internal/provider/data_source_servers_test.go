@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestServersDataSource_Schema(t *testing.T) {
+	ds := NewServersDataSource()
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"hostname_prefix", "hostname_regex", "location_id", "plan_id", "os_id", "status", "ids", "servers"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+		}
+	}
+
+	mresp := &datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "rackdog"}, mresp)
+	if mresp.TypeName != "rackdog_servers" {
+		t.Errorf("expected TypeName 'rackdog_servers', got %s", mresp.TypeName)
+	}
+}
+
+func TestFilterServers(t *testing.T) {
+	server := func(id, hostname string, plan, location, os int, status string) Server {
+		s := Server{ID: id, Plan: ServerPlan{ID: plan}, Location: ServerLocation{ID: location}, PowerStatus: &status}
+		if hostname != "" {
+			s.Hostname = &hostname
+		}
+		if os != 0 {
+			s.ServerOS = &ServerOS{ID: os}
+		}
+		return s
+	}
+	servers := []Server{
+		server("a", "web-01", 10, 1, 62, "ON"),
+		server("b", "web-02", 12, 2, 70, "OFF"),
+		server("c", "db-01", 12, 1, 62, "ON"),
+		server("d", "", 10, 1, 0, "PROVISIONING"),
+	}
+	servers[0].Tags = map[string]string{"env": "prod", "team": "web"}
+	servers[1].Tags = map[string]string{"env": "staging", "team": "web"}
+	servers[2].Tags = map[string]string{"env": "prod"}
+
+	tests := []struct {
+		name   string
+		filter serverFilter
+		want   []string
+	}{
+		{"no filter", serverFilter{}, []string{"a", "b", "c", "d"}},
+		{"hostname prefix", serverFilter{HostnamePrefix: "web-"}, []string{"a", "b"}},
+		{"hostname regex", serverFilter{HostnameRegex: regexp.MustCompile(`-01$`)}, []string{"a", "c"}},
+		{"location and plan", serverFilter{LocationID: 1, PlanID: 12}, []string{"c"}},
+		{"os skips servers without one", serverFilter{OSID: 62}, []string{"a", "c"}},
+		{"status is case-insensitive", serverFilter{Status: "off"}, []string{"b"}},
+		{"tags", serverFilter{Tags: map[string]string{"env": "prod"}}, []string{"a", "c"}},
+		{"all tags must match", serverFilter{Tags: map[string]string{"env": "prod", "team": "web"}}, []string{"a"}},
+		{"no match", serverFilter{HostnamePrefix: "web-", Status: "PROVISIONING"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterServers(servers, tt.filter)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, got)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Fatalf("expected %v, got %+v", tt.want, got)
+				}
+			}
+		})
+	}
+}
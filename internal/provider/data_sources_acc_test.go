@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccNotEmpty checks that a list attribute has elements.
+func testAccNotEmpty(addr, list string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(addr, list+".#", func(n string) error {
+		if count, _ := strconv.Atoi(n); count == 0 {
+			return fmt.Errorf("expected %s to have elements", list)
+		}
+		return nil
+	})
+}
+
+// testAccIfFake runs checks only against the fake API, whose catalog is
+// known.
+func (e *accEnv) testAccIfFake(checks ...resource.TestCheckFunc) resource.TestCheckFunc {
+	if e.fake == nil {
+		return resource.ComposeAggregateTestCheckFunc()
+	}
+	return resource.ComposeAggregateTestCheckFunc(checks...)
+}
+
+func TestAccDataSources(t *testing.T) {
+	e := newAccEnv(t)
+	config := e.config(nil,
+		testAccDataSource("rackdog_plans", "la", map[string]any{"location": "la"}),
+		testAccDataSource("rackdog_plan", "cheapest", map[string]any{"location": "ny", "min_ram": 64, "selection": "cheapest"}),
+		testAccDataSource("rackdog_operating_systems", "all", nil),
+		testAccDataSource("rackdog_operating_system", "ubuntu", map[string]any{"family": "Ubuntu", "latest": true}),
+		testAccDataSource("rackdog_locations", "us", map[string]any{"country": "US"}),
+		testAccDataSource("rackdog_location", "ny", map[string]any{"keyword": "ny"}),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccNotEmpty("data.rackdog_plans.la", "plans"),
+					resource.TestCheckResourceAttrSet("data.rackdog_plan.cheapest", "id"),
+					resource.TestCheckResourceAttrSet("data.rackdog_plan.cheapest", "monthly_price"),
+					testAccNotEmpty("data.rackdog_operating_systems.all", "operating_systems"),
+					resource.TestCheckResourceAttr("data.rackdog_operating_system.ubuntu", "family", "Ubuntu"),
+					resource.TestCheckResourceAttrSet("data.rackdog_operating_system.ubuntu", "version"),
+					testAccNotEmpty("data.rackdog_locations.us", "locations"),
+					resource.TestCheckResourceAttrSet("data.rackdog_location.ny", "id"),
+					resource.TestCheckResourceAttrSet("data.rackdog_location.ny", "name"),
+					e.testAccIfFake(
+						resource.TestCheckResourceAttr("data.rackdog_plan.cheapest", "id", "11"),
+						resource.TestCheckResourceAttr("data.rackdog_plan.cheapest", "monthly_price", "149"),
+						resource.TestCheckResourceAttr("data.rackdog_operating_system.ubuntu", "id", "62"),
+					),
+				),
+			},
+			{
+				Config:      e.config(nil, testAccDataSource("rackdog_plan", "none", map[string]any{"min_ram": 100000})),
+				ExpectError: regexp.MustCompile(`No unique plan`),
+			},
+			{
+				Config:      e.config(nil, testAccDataSource("rackdog_operating_system", "mixed", map[string]any{"name_regex": "Ubuntu|Debian", "latest": true})),
+				ExpectError: regexp.MustCompile(`No unique operating system`),
+			},
+			{
+				Config:      e.config(nil, testAccDataSource("rackdog_location", "nowhere", map[string]any{"keyword": "nowhere"})),
+				ExpectError: regexp.MustCompile(`Location not found`),
+			},
+		},
+	})
+}
+
+func TestAccServerDataSource(t *testing.T) {
+	e := newAccEnv(t)
+	server := testAccServerConfig(map[string]any{"hostname": "acc-lookup-01"})
+	const byID, byHostname = "data.rackdog_server.by_id", "data.rackdog_server.by_hostname"
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(byID, "plan.id", "10"),
+		resource.TestCheckResourceAttr(byID, "location.id", "1"),
+		resource.TestCheckResourceAttr(byID, "os.id", "62"),
+	}
+	for _, attr := range []string{"id", "hostname", "ip_address", "status", "power_state", "monthly_price"} {
+		checks = append(checks,
+			resource.TestCheckResourceAttrPair(byID, attr, testAccServerAddr, attr),
+			resource.TestCheckResourceAttrPair(byHostname, attr, testAccServerAddr, attr),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, server,
+					testAccDataSource("rackdog_server", "by_id", map[string]any{"id": hclExpr("rackdog_server.test.id")}),
+					testAccDataSource("rackdog_server", "by_hostname", map[string]any{"hostname": hclExpr("rackdog_server.test.hostname")}),
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+			{
+				Config:      e.config(nil, server, testAccDataSource("rackdog_server", "missing", map[string]any{"hostname": "acc-missing"})),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Server not found`),
+			},
+			{
+				Config: e.config(nil, server, testAccDataSource("rackdog_server", "both", map[string]any{
+					"id": hclExpr("rackdog_server.test.id"), "hostname": "acc-lookup-01",
+				})),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid server lookup`),
+			},
+		},
+	})
+}
+
+func TestAccServersDataSource(t *testing.T) {
+	e := newAccEnv(t)
+	servers := []string{
+		testAccResource("rackdog_server", "web1", map[string]any{
+			"plan_id": 10, "location_id": 1, "os_id": 62, "raid": 1,
+			"hostname": "acc-list-web-01", "tags": map[string]string{"tier": "front"},
+		}),
+		testAccResource("rackdog_server", "web2", map[string]any{
+			"plan_id": 10, "location_id": 2, "os_id": 62, "raid": 1, "hostname": "acc-list-web-02",
+		}),
+		testAccResource("rackdog_server", "db1", map[string]any{
+			"plan_id": 12, "location_id": 1, "os_id": 62, "raid": 1, "hostname": "acc-list-db-01",
+		}),
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   []string
+	}{
+		{"prefix", map[string]any{"hostname_prefix": "acc-list-web-"}, []string{"web1", "web2"}},
+		{"regex", map[string]any{"hostname_regex": `^acc-list-.*-01$`}, []string{"web1", "db1"}},
+		{"location", map[string]any{"hostname_prefix": "acc-list-", "location_id": 2}, []string{"web2"}},
+		{"plan_and_status", map[string]any{"hostname_prefix": "acc-list-", "plan_id": 12, "status": "on"}, []string{"db1"}},
+		{"tags", map[string]any{"tags": map[string]string{"tier": "front"}}, []string{"web1"}},
+		{"no_match", map[string]any{"hostname_prefix": "acc-list-", "os_id": 70}, nil},
+	}
+	blocks := slices.Clone(servers)
+	var checks []resource.TestCheckFunc
+	for _, tt := range tests {
+		blocks = append(blocks, testAccDataSource("rackdog_servers", tt.name, tt.config))
+		addr := "data.rackdog_servers." + tt.name
+		checks = append(checks,
+			resource.TestCheckResourceAttr(addr, "ids.#", fmt.Sprint(len(tt.want))),
+			resource.TestCheckResourceAttr(addr, "servers.#", fmt.Sprint(len(tt.want))),
+		)
+		for _, name := range tt.want {
+			checks = append(checks, resource.TestCheckTypeSetElemAttrPair(addr, "ids.*", "rackdog_server."+name, "id"))
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			// The lists are read while planning, so the servers come first.
+			{
+				Config: e.config(nil, servers...),
+			},
+			{
+				Config: e.config(nil, blocks...),
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+			{
+				Config:      e.config(nil, append(servers, testAccDataSource("rackdog_servers", "bad", map[string]any{"hostname_regex": "("}))...),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid regular expression`),
+			},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Drift policies decide what Read does when the remote object no longer
+// matches state.
+const (
+	// driftPolicyError fails the refresh until someone reconciles by hand.
+	driftPolicyError = "error"
+	// driftPolicyWarn keeps state as-is and reports a warning.
+	driftPolicyWarn = "warn"
+	// driftPolicyAdopt writes the remote value into state so the next plan
+	// shows a normal diff (or replacement).
+	driftPolicyAdopt = "adopt"
+)
+
+var driftPolicies = []string{driftPolicyError, driftPolicyWarn, driftPolicyAdopt}
+
+// drift describes one attribute whose remote value differs from state.
+type drift struct {
+	attr   string
+	state  string
+	remote string
+	adopt  func()
+}
+
+// applyDrift handles drifts according to policy. It returns false when
+// Read must stop because an error was recorded.
+func applyDrift(diags *diag.Diagnostics, policy string, drifts []drift) bool {
+	for _, d := range drifts {
+		summary := fmt.Sprintf("Out-of-band change detected (%s)", d.attr)
+		detail := fmt.Sprintf("Remote %s is %s but state expected %s. This likely happened outside Terraform (portal/api).",
+			d.attr, d.remote, d.state)
+		switch policy {
+		case driftPolicyAdopt:
+			d.adopt()
+		case driftPolicyWarn:
+			diags.AddWarning(summary, detail+" State is kept as-is because drift_policy is \"warn\".")
+		default:
+			diags.AddError(summary, detail+" Please reconcile: either update your config to match, import the correct resource, "+
+				"replace this server, or set drift_policy to \"warn\" or \"adopt\".")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestServerDrift_Policies(t *testing.T) {
+	hostname := "renamed-01"
+	raid := 1
+	remote := &Server{
+		ID:       "server-123",
+		Plan:     ServerPlan{ID: 11},
+		Location: ServerLocation{ID: 1},
+		ServerOS: &ServerOS{ID: 62},
+		Raid:     &raid,
+		Hostname: &hostname,
+	}
+	newState := func() serverModel {
+		return serverModel{
+			ID:         types.StringValue("server-123"),
+			PlanID:     types.Int64Value(10),
+			LocationID: types.Int64Value(1),
+			OSID:       types.Int64Value(62),
+			Raid:       types.Int64Value(1),
+			Hostname:   types.StringValue("web-01"),
+		}
+	}
+
+	tests := []struct {
+		policy       string
+		wantOK       bool
+		wantErrors   int
+		wantWarnings int
+		wantHostname string
+		wantPlanID   int64
+	}{
+		{policy: driftPolicyError, wantOK: false, wantErrors: 1, wantHostname: "web-01", wantPlanID: 10},
+		{policy: driftPolicyWarn, wantOK: true, wantWarnings: 2, wantHostname: "web-01", wantPlanID: 10},
+		{policy: driftPolicyAdopt, wantOK: true, wantHostname: "renamed-01", wantPlanID: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			state := newState()
+			drifts := serverDrift(&state, remote)
+			if len(drifts) != 2 {
+				t.Fatalf("expected hostname and plan_id drift, got %+v", drifts)
+			}
+
+			var diags diag.Diagnostics
+			ok := applyDrift(&diags, tt.policy, drifts)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if diags.ErrorsCount() != tt.wantErrors || diags.WarningsCount() != tt.wantWarnings {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if state.Hostname.ValueString() != tt.wantHostname {
+				t.Errorf("expected hostname %q, got %q", tt.wantHostname, state.Hostname.ValueString())
+			}
+			if state.PlanID.ValueInt64() != tt.wantPlanID {
+				t.Errorf("expected plan_id %d, got %d", tt.wantPlanID, state.PlanID.ValueInt64())
+			}
+		})
+	}
+}
+
+func TestServerDrift_NoneAfterImport(t *testing.T) {
+	hostname := "web-01"
+	state := serverModel{ID: types.StringValue("server-123"), Hostname: types.StringNull()}
+	remote := &Server{Plan: ServerPlan{ID: 10}, Location: ServerLocation{ID: 1}, Hostname: &hostname}
+
+	if drifts := serverDrift(&state, remote); len(drifts) != 0 {
+		t.Fatalf("expected no drift for unset attributes, got %+v", drifts)
+	}
+}
+
+func TestTagsDrift(t *testing.T) {
+	defaults := map[string]string{"env": "prod"}
+	tests := []struct {
+		name      string
+		tagsAll   types.Map
+		remote    map[string]string
+		wantDrift bool
+	}{
+		{"untagged", tagsAllValue(nil), nil, false},
+		{"tagged outside Terraform", tagsAllValue(nil), map[string]string{"team": "web"}, true},
+		{"untagged outside Terraform", tagsAllValue(map[string]string{"team": "web"}), nil, true},
+		{"never recorded", types.MapNull(types.StringType), map[string]string{"team": "web"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := serverModel{TagsAll: tt.tagsAll}
+			drifts := tagsDrift(&state, &Server{Tags: tt.remote}, defaults)
+			if len(drifts) > 0 != tt.wantDrift {
+				t.Fatalf("expected drift %v, got %+v", tt.wantDrift, drifts)
+			}
+			if !tt.wantDrift {
+				return
+			}
+			drifts[0].adopt()
+			if got, _ := knownTags(state.TagsAll); state.TagsAll.IsNull() || !maps.Equal(got, tt.remote) {
+				t.Errorf("expected tags_all %v adopted, got %v", tt.remote, state.TagsAll)
+			}
+		})
+	}
+}
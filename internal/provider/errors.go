@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// APIError is returned for any failed Rackdog API call: a non-2xx status or
+// an envelope with success=false. Message, Code and Fields are decoded from
+// the envelope when the body has one.
+type APIError struct {
+	Status  int
+	Method  string
+	URL     string
+	Code    string
+	Message string
+	Fields  []FieldError
+	Body    string
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+// FieldError is a validation failure the API attributes to one request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
+	}
+	var b strings.Builder
+	if e.Status >= 200 && e.Status < 300 {
+		fmt.Fprintf(&b, "%s %s failed: %s", e.Method, e.URL, msg)
+	} else {
+		fmt.Fprintf(&b, "%s %s failed: %d - %s", e.Method, e.URL, e.Status, msg)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, "; %s: %s", f.Field, f.Message)
+	}
+	return b.String()
+}
+
+func (e *APIError) hasCode(codes ...string) bool {
+	for _, c := range codes {
+		if strings.EqualFold(e.Code, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var ae *APIError
+	ok := errors.As(err, &ae)
+	return ae, ok
+}
+
+func IsNotFound(err error) bool {
+	ae, ok := asAPIError(err)
+	return ok && (ae.Status == http.StatusNotFound || ae.hasCode("not_found"))
+}
+
+func IsConflict(err error) bool {
+	ae, ok := asAPIError(err)
+	return ok && (ae.Status == http.StatusConflict || ae.hasCode("conflict"))
+}
+
+func IsRateLimited(err error) bool {
+	ae, ok := asAPIError(err)
+	return ok && (ae.Status == http.StatusTooManyRequests || ae.hasCode("rate_limited"))
+}
+
+func IsValidation(err error) bool {
+	ae, ok := asAPIError(err)
+	return ok && (ae.Status == http.StatusBadRequest || ae.Status == http.StatusUnprocessableEntity ||
+		len(ae.Fields) > 0 || ae.hasCode("validation_error", "validation"))
+}
+
+// errorEnvelope is the subset of the Rackdog envelope that describes a
+// failure. errors comes as a list of {field, message} objects or as a map
+// of field to message(s), depending on the endpoint.
+type errorEnvelope struct {
+	Success *bool           `json:"success"`
+	Message string          `json:"message"`
+	Error   string          `json:"error"`
+	Code    string          `json:"code"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// decodeEnvelope fills Message, Code and Fields from body. Bodies that
+// are not JSON are left in Body only.
+func (e *APIError) decodeEnvelope(body []byte) {
+	var env errorEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return
+	}
+	e.Message = env.Message
+	if e.Message == "" {
+		e.Message = env.Error
+	}
+	e.Code = env.Code
+	e.Fields = parseFieldErrors(env.Errors)
+}
+
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+	var list []FieldError
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var out []FieldError
+	for _, k := range keys {
+		var one string
+		if err := json.Unmarshal(m[k], &one); err == nil {
+			out = append(out, FieldError{Field: k, Message: one})
+			continue
+		}
+		var many []string
+		if err := json.Unmarshal(m[k], &many); err == nil {
+			for _, msg := range many {
+				out = append(out, FieldError{Field: k, Message: msg})
+			}
+		}
+	}
+	return out
+}
+
+// appendAPIError adds err to diags. Field errors whose API field name is in
+// fields are attached to that attribute; anything else becomes a general
+// error so nothing is lost.
+func appendAPIError(diags *diag.Diagnostics, summary string, err error, fields map[string]path.Path) {
+	ae, ok := asAPIError(err)
+	if !ok || len(ae.Fields) == 0 {
+		diags.AddError(summary, err.Error())
+		return
+	}
+	unmapped := false
+	for _, f := range ae.Fields {
+		if p, ok := fields[f.Field]; ok {
+			diags.AddAttributeError(p, summary, f.Message)
+		} else {
+			unmapped = true
+		}
+	}
+	if unmapped {
+		diags.AddError(summary, err.Error())
+	}
+}
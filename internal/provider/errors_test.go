@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAPIError_Envelope(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantCode   string
+		wantFields []FieldError
+		check      func(error) bool
+	}{
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `{"success": false, "message": "Server not found"}`,
+			check:  IsNotFound,
+		},
+		{
+			name:     "not found by code on 200",
+			status:   http.StatusOK,
+			body:     `{"success": false, "message": "gone", "code": "NOT_FOUND"}`,
+			wantCode: "NOT_FOUND",
+			check:    IsNotFound,
+		},
+		{
+			name:   "conflict",
+			status: http.StatusConflict,
+			body:   `{"success": false, "message": "hostname taken"}`,
+			check:  IsConflict,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			body:   `slow down`,
+			check:  IsRateLimited,
+		},
+		{
+			name:       "validation list",
+			status:     http.StatusUnprocessableEntity,
+			body:       `{"success": false, "message": "invalid", "code": "validation_error", "errors": [{"field": "hostname", "message": "too long"}]}`,
+			wantCode:   "validation_error",
+			wantFields: []FieldError{{Field: "hostname", Message: "too long"}},
+			check:      IsValidation,
+		},
+		{
+			name:       "validation map",
+			status:     http.StatusOK,
+			body:       `{"success": false, "message": "invalid", "errors": {"raid": ["unsupported"], "osId": "unknown"}}`,
+			wantFields: []FieldError{{Field: "osId", Message: "unknown"}, {Field: "raid", Message: "unsupported"}},
+			check:      IsValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, "k123", WithRetryPolicy(RetryPolicy{}))
+			_, err := c.GetServer(context.Background(), "server-123")
+			ae, ok := asAPIError(err)
+			if !ok {
+				t.Fatalf("expected APIError, got %v", err)
+			}
+			if !tt.check(err) {
+				t.Fatalf("sentinel check failed for %v", err)
+			}
+			if ae.Code != tt.wantCode {
+				t.Fatalf("expected code %q, got %q", tt.wantCode, ae.Code)
+			}
+			if len(ae.Fields) != len(tt.wantFields) {
+				t.Fatalf("expected fields %+v, got %+v", tt.wantFields, ae.Fields)
+			}
+			for i := range tt.wantFields {
+				if ae.Fields[i] != tt.wantFields[i] {
+					t.Fatalf("expected fields %+v, got %+v", tt.wantFields, ae.Fields)
+				}
+			}
+		})
+	}
+}
+
+func TestAppendAPIError(t *testing.T) {
+	err := &APIError{
+		Status:  http.StatusUnprocessableEntity,
+		Message: "invalid",
+		Fields: []FieldError{
+			{Field: "hostname", Message: "too long"},
+			{Field: "sku", Message: "unknown"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	appendAPIError(&diags, "Create failed", err, serverFieldPaths)
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("hostname")) {
+		t.Fatalf("expected first error on hostname, got %v", diags[0])
+	}
+}
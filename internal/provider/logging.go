@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem for API traffic. Its level follows
+// TF_LOG_PROVIDER_RACKDOG_HTTP, falling back to the provider's level.
+const httpLogSubsystem = "rackdog_http"
+
+// maxLoggedBody caps how much of a request or response body is logged.
+const maxLoggedBody = 16 << 10
+
+const redacted = "***"
+
+// sensitiveFields are JSON keys whose values never appear in logs. Keys are
+// compared lower-cased with '_' and '-' removed.
+var sensitiveFields = map[string]bool{
+	"apikey":       true,
+	"xrdkey":       true,
+	"password":     true,
+	"rootpassword": true,
+	"secret":       true,
+	"token":        true,
+	"accesstoken":  true,
+	"privatekey":   true,
+	"userdata":     true,
+}
+
+func isSensitiveField(key string) bool {
+	k := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveFields[k]
+}
+
+// httpLogContext sets up the rackdog_http subsystem on ctx. The API key is
+// masked wherever it appears in a field value, as a backstop to
+// redactBody.
+func (c *Client) httpLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem,
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER_RACKDOG_HTTP"),
+		tflog.WithRootFields(),
+	)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, httpLogSubsystem, "x-rd-key")
+	if c.apiKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, httpLogSubsystem, c.apiKey)
+	}
+	return ctx
+}
+
+// newRequestID returns a random ID sent as X-Request-Id so a logged request
+// can be matched with the API's own logs.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// redactBody renders a body for logging with sensitive JSON fields masked.
+// Bodies that are not JSON are logged as is, truncated to maxLoggedBody.
+func redactBody(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err == nil {
+		if out, err := json.Marshal(redactValue(v)); err == nil {
+			b = out
+		}
+	}
+	if len(b) > maxLoggedBody {
+		return string(b[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(b)
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSensitiveField(k) {
+				t[k] = redacted
+			} else {
+				t[k] = redactValue(val)
+			}
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+	}
+	return v
+}
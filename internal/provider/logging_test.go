@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"hostname":"web-01","rootPassword":"hunter2","nested":[{"api_key":"k"}]}`))
+	if strings.Contains(got, "hunter2") || strings.Contains(got, `"k"`) {
+		t.Fatalf("sensitive values leaked: %s", got)
+	}
+	if !strings.Contains(got, "web-01") {
+		t.Fatalf("expected non-sensitive values to be kept: %s", got)
+	}
+	if got := redactBody([]byte("not json")); got != "not json" {
+		t.Fatalf("expected plain body unchanged, got %q", got)
+	}
+	if got := redactBody(bytes.Repeat([]byte("a"), maxLoggedBody+10)); !strings.HasSuffix(got, "...(truncated)") {
+		t.Fatal("expected long body to be truncated")
+	}
+}
+
+func TestClientHTTPLogging(t *testing.T) {
+	var gotRequestID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = r.Header.Get("X-Request-Id")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    map[string]any{"id": "server-123", "rootPassword": "hunter2"},
+		})
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	c := NewClient(srv.URL, "secret-key-123")
+	hostname := "web-01"
+	if _, err := c.CreateServer(ctx, &CreateServerRequest{PlanID: 1, LocationID: 1, OSID: 1, Hostname: &hostname}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatalf("decoding logs: %v", err)
+	}
+	if strings.Contains(out.String(), "secret-key-123") || strings.Contains(out.String(), "hunter2") {
+		t.Fatalf("secrets leaked into logs: %s", out.String())
+	}
+
+	var response map[string]any
+	for _, e := range entries {
+		if e["@message"] == "Received Rackdog API response" {
+			response = e
+		}
+	}
+	if response == nil {
+		t.Fatalf("no response log entry in %v", entries)
+	}
+	if response["@module"] != "provider."+httpLogSubsystem {
+		t.Errorf("expected module provider.%s, got %v", httpLogSubsystem, response["@module"])
+	}
+	if response["method"] != "POST" || response["path"] != "/v1/ordering/allocate" || response["status"] != float64(200) {
+		t.Errorf("unexpected request fields: %v", response)
+	}
+	if _, ok := response["latency_ms"]; !ok {
+		t.Errorf("expected latency_ms in %v", response)
+	}
+	if !strings.Contains(response["request_body"].(string), "web-01") {
+		t.Errorf("expected request body in %v", response)
+	}
+	if gotRequestID == "" || response["request_id"] != gotRequestID {
+		t.Errorf("expected request_id %q to match the X-Request-Id header, got %v", gotRequestID, response["request_id"])
+	}
+}
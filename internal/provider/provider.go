@@ -2,13 +2,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,18 +30,36 @@ func New(version string) func() provider.Provider {
 }
 
 type providerModel struct {
-	Endpoint          types.String `tfsdk:"endpoint"`
-	APIKey            types.String `tfsdk:"api_key"`
-	RecreateOnMissing types.Bool   `tfsdk:"recreate_on_missing"`
+	Endpoint          types.String      `tfsdk:"endpoint"`
+	APIKey            types.String      `tfsdk:"api_key"`
+	RecreateOnMissing types.Bool        `tfsdk:"recreate_on_missing"`
+	MaxRetries        types.Int64       `tfsdk:"max_retries"`
+	RetryMaxWait      types.String      `tfsdk:"retry_max_wait"`
+	DriftPolicy       types.String      `tfsdk:"drift_policy"`
+	MaxMonthlySpend   types.Float64     `tfsdk:"max_monthly_spend_per_server"`
+	DefaultTags       *defaultTagsModel `tfsdk:"default_tags"`
+}
+
+type defaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
 }
 
 type resolvedConfig struct {
-	RecreateOnMissing bool
+	RecreateOnMissing        bool
+	DriftPolicy              string
+	MaxMonthlySpendPerServer float64
+	// DefaultTags are merged into every server's tags_all.
+	DefaultTags map[string]string
+	// DefaultTagsUnknown is set when default_tags depends on values not
+	// known until apply.
+	DefaultTagsUnknown bool
 }
 
 type ProviderData struct {
 	Client *Client
-	Cfg    resolvedConfig
+	// Catalog caches plans, operating systems and locations for the run.
+	Catalog *catalog
+	Cfg     resolvedConfig
 }
 
 func (p *rackdogProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -60,6 +84,37 @@ func (p *rackdogProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Description: "If true, resources missing on Read (404) will be removed from state so Terraform can recreate them.",
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of retries for rate-limited (429) or transiently failing API requests. Defaults to 4, or RACKDOG_MAX_RETRIES.",
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "Upper bound on the backoff between retries, e.g. \"30s\". A longer Retry-After from the API is still honoured, up to 5 minutes. Defaults to 30s, or RACKDOG_RETRY_MAX_WAIT.",
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"drift_policy": schema.StringAttribute{
+				Optional: true,
+				Description: "What resources do when Read finds out-of-band changes: \"error\" (default) fails the refresh, " +
+					"\"warn\" keeps state and warns, \"adopt\" writes remote values into state. Defaults to RACKDOG_DRIFT_POLICY.",
+				Validators: []validator.String{stringOneOf(driftPolicies...)},
+			},
+			"max_monthly_spend_per_server": schema.Float64Attribute{
+				Optional: true,
+				Description: "If set, planning a rackdog_server whose monthly price exceeds this amount fails. " +
+					"Defaults to RACKDOG_MAX_MONTHLY_SPEND_PER_SERVER.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags added to every rackdog_server. Tags set on the resource override these.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -85,10 +140,73 @@ func (p *rackdogProvider) Configure(ctx context.Context, req provider.ConfigureR
 		recreate = strings.EqualFold(v, "1") || strings.EqualFold(v, "true")
 	}
 
-	client := NewClient(endpoint, key)
+	drift := getString(config.DriftPolicy, "RACKDOG_DRIFT_POLICY", driftPolicyError)
+	if !slices.Contains(driftPolicies, drift) {
+		resp.Diagnostics.AddAttributeError(path.Root("drift_policy"), "Invalid drift_policy",
+			fmt.Sprintf("%q is not allowed; use one of %s.", drift, quoteJoin(driftPolicies)))
+		return
+	}
+
+	var maxSpend float64
+	if !config.MaxMonthlySpend.IsNull() && !config.MaxMonthlySpend.IsUnknown() {
+		maxSpend = config.MaxMonthlySpend.ValueFloat64()
+	} else if v := os.Getenv("RACKDOG_MAX_MONTHLY_SPEND_PER_SERVER"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid RACKDOG_MAX_MONTHLY_SPEND_PER_SERVER", err.Error())
+			return
+		}
+		maxSpend = f
+	}
+
+	var defaultTags map[string]string
+	defaultTagsKnown := true
+	if config.DefaultTags != nil {
+		defaultTags, defaultTagsKnown = knownTags(config.DefaultTags.Tags)
+		if err := checkTags(defaultTags); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_tags").AtName("tags"), "Invalid default_tags", err.Error())
+			return
+		}
+	}
+
+	retry := DefaultRetryPolicy
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	} else if v := os.Getenv("RACKDOG_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid RACKDOG_MAX_RETRIES", err.Error())
+			return
+		}
+		retry.MaxRetries = n
+	}
+	if retry.MaxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries", "max_retries must not be negative.")
+		return
+	}
+
+	if wait := getString(config.RetryMaxWait, "RACKDOG_RETRY_MAX_WAIT", ""); wait != "" {
+		d, err := time.ParseDuration(wait)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid retry_max_wait",
+				fmt.Sprintf("%q is not a valid positive duration.", wait))
+			return
+		}
+		retry.MaxWait = d
+		retry.MinWait = min(retry.MinWait, d)
+	}
+
+	client := NewClient(endpoint, key, WithRetryPolicy(retry))
 	pd := &ProviderData{
-		Client: client,
-		Cfg:    resolvedConfig{RecreateOnMissing: recreate},
+		Client:  client,
+		Catalog: newCatalog(client, defaultCatalogTTL),
+		Cfg: resolvedConfig{
+			RecreateOnMissing:        recreate,
+			DriftPolicy:              drift,
+			MaxMonthlySpendPerServer: maxSpend,
+			DefaultTags:              defaultTags,
+			DefaultTagsUnknown:       !defaultTagsKnown,
+		},
 	}
 
 	resp.DataSourceData = pd
@@ -97,19 +215,32 @@ func (p *rackdogProvider) Configure(ctx context.Context, req provider.ConfigureR
 	tflog.Info(ctx, "Rackdog provider configured", map[string]any{
 		"endpoint":            endpoint,
 		"recreate_on_missing": recreate,
+		"max_retries":         retry.MaxRetries,
+		"retry_max_wait":      retry.MaxWait.String(),
+		"drift_policy":        drift,
+		"max_monthly_spend":   maxSpend,
+		"default_tags":        len(defaultTags),
 	})
 }
 
 func (p *rackdogProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewServerResource,
+		NewSSHKeyResource,
+		NewReverseDNSResource,
 	}
 }
 
 func (p *rackdogProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPlansDataSource,
+		NewPlanDataSource,
 		NewOperatingSystemsDataSource,
+		NewOperatingSystemDataSource,
+		NewLocationsDataSource,
+		NewLocationDataSource,
+		NewServerDataSource,
+		NewServersDataSource,
 	}
 }
 
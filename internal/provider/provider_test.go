@@ -35,7 +35,7 @@ func TestProvider_Schema(t *testing.T) {
 	}
 
 	// required attributes
-	attrs := []string{"endpoint", "api_key", "recreate_on_missing"}
+	attrs := []string{"endpoint", "api_key", "recreate_on_missing", "max_retries", "retry_max_wait", "drift_policy", "max_monthly_spend_per_server"}
 	for _, attr := range attrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected '%s' attribute in schema", attr)
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type reverseDNSResource struct {
+	client *Client
+	cfg    resolvedConfig
+}
+
+var (
+	_ resource.ResourceWithImportState    = &reverseDNSResource{}
+	_ resource.ResourceWithValidateConfig = &reverseDNSResource{}
+	_ resource.ResourceWithModifyPlan     = &reverseDNSResource{}
+)
+
+// reverseDNSFieldPaths maps reverse DNS request fields to schema attributes.
+var reverseDNSFieldPaths = map[string]path.Path{
+	"ptr": path.Root("ptr"),
+}
+
+func NewReverseDNSResource() resource.Resource { return &reverseDNSResource{} }
+
+type reverseDNSModel struct {
+	ID        types.String `tfsdk:"id"`
+	IPAddress types.String `tfsdk:"ip_address"`
+	PTR       types.String `tfsdk:"ptr"`
+	ServerID  types.String `tfsdk:"server_id"`
+}
+
+func (r *reverseDNSResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reverse_dns"
+}
+
+func (r *reverseDNSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the reverse DNS (PTR) record of a server IP address. Destroying it restores the default record.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The IP address.",
+			},
+			"ip_address": schema.StringAttribute{
+				Required:    true,
+				Description: "IPv4 or IPv6 address of a server in the account, e.g. rackdog_server.relay.ip_address. Changing it replaces the record.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ptr": schema.StringAttribute{
+				Required:    true,
+				Description: "Fully qualified domain name the address resolves to, e.g. \"mail.example.com\". Changed in place.",
+			},
+			"server_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the server the address belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *reverseDNSResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	r.client = pd.Client
+	r.cfg = pd.Cfg
+}
+
+func (r *reverseDNSResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config reverseDNSModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ip := config.IPAddress; !ip.IsNull() && !ip.IsUnknown() {
+		if _, err := netip.ParseAddr(ip.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid IP address",
+				fmt.Sprintf("%q is not an IPv4 or IPv6 address.", ip.ValueString()))
+		}
+	}
+	if ptr := config.PTR; !ptr.IsNull() && !ptr.IsUnknown() {
+		if err := checkPTR(ptr.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ptr"), "Invalid PTR record", err.Error())
+		}
+	}
+}
+
+// checkPTR checks that s is a fully qualified domain name, optionally with
+// a trailing dot.
+func checkPTR(s string) error {
+	name := strings.TrimSuffix(s, ".")
+	if len(name) > 253 || !hostnameRe.MatchString(name) {
+		return fmt.Errorf("%q is not a valid domain name: use letters, digits and hyphens in dot-separated labels of at most 63 characters", s)
+	}
+	if !strings.Contains(name, ".") {
+		return fmt.Errorf("%q is not fully qualified; use a name such as \"mail.example.com\"", s)
+	}
+	return nil
+}
+
+// samePTR reports whether two PTR names are equal, ignoring case and a
+// trailing dot.
+func samePTR(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// ModifyPlan checks at plan time that a new ip_address belongs to a server
+// in the account, and plans id and server_id from it. Addresses not known
+// until apply, such as a new server's, are left to the API to check.
+func (r *reverseDNSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state reverseDNSModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), plan.IPAddress)...)
+	if plan.IPAddress.Equal(state.IPAddress) {
+		return
+	}
+	serverID := types.StringUnknown()
+	if ip := plan.IPAddress; !ip.IsUnknown() && r.client != nil {
+		s, err := r.findServerByIP(ctx, ip.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list servers", err.Error())
+			return
+		}
+		if s == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "IP address not in account",
+				fmt.Sprintf("No server in this account has IP address %s. Use the ip_address of a rackdog_server.", ip.ValueString()))
+			return
+		}
+		serverID = types.StringValue(s.ID)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("server_id"), serverID)...)
+}
+
+// findServerByIP returns the server with the given address, or nil when
+// there is none.
+func (r *reverseDNSResource) findServerByIP(ctx context.Context, ip string) (*Server, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, nil // reported by ValidateConfig
+	}
+	servers, err := r.client.ListServers(ctx, ListServersOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if a, err := netip.ParseAddr(servers[i].IPAddress); err == nil && a == addr {
+			return &servers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *reverseDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var plan reverseDNSModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rec, err := r.client.SetReverseDNS(ctx, plan.IPAddress.ValueString(), plan.PTR.ValueString())
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Create failed", err, reverseDNSFieldPaths)
+		return
+	}
+
+	plan.ID = plan.IPAddress
+	plan.ServerID = types.StringValue(rec.ServerID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *reverseDNSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var state reverseDNSModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rec, err := r.client.GetReverseDNS(ctx, state.IPAddress.ValueString())
+	if err != nil {
+		// The address left the account along with its server.
+		if IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	if state.PTR.IsNull() {
+		// After an import only the address is known.
+		state.PTR = types.StringValue(rec.PTR)
+	} else if !samePTR(state.PTR.ValueString(), rec.PTR) {
+		remote := rec.PTR
+		drifts := []drift{{
+			attr:   "ptr",
+			state:  fmt.Sprintf("%q", state.PTR.ValueString()),
+			remote: fmt.Sprintf("%q", remote),
+			adopt:  func() { state.PTR = types.StringValue(remote) },
+		}}
+		if !applyDrift(&resp.Diagnostics, r.cfg.DriftPolicy, drifts) {
+			return
+		}
+	}
+	state.ServerID = types.StringValue(rec.ServerID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *reverseDNSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var plan reverseDNSModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rec, err := r.client.SetReverseDNS(ctx, plan.IPAddress.ValueString(), plan.PTR.ValueString())
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Update failed", err, reverseDNSFieldPaths)
+		return
+	}
+
+	plan.ServerID = types.StringValue(rec.ServerID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *reverseDNSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var state reverseDNSModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.ResetReverseDNS(ctx, state.IPAddress.ValueString()); err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ImportState accepts the IP address.
+func (r *reverseDNSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := netip.ParseAddr(req.ID); err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected an IP address, got %q.", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), req.ID)...)
+}
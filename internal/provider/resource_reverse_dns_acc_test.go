@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testAccReverseDNSAddr = "rackdog_reverse_dns.test"
+
+func testAccReverseDNSConfig(ptr string) string {
+	return testAccResource("rackdog_reverse_dns", "test", map[string]any{
+		"ip_address": hclExpr("rackdog_server.test.ip_address"),
+		"ptr":        ptr,
+	})
+}
+
+// checkPTR fails unless the API's PTR record for the server's address
+// matches want, or differs from it when want is negated.
+func (e *accEnv) checkPTR(want string, negate bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ip := s.RootModule().Resources[testAccServerAddr].Primary.Attributes["ip_address"]
+		rec, err := e.client.GetReverseDNS(context.Background(), ip)
+		if err != nil {
+			return err
+		}
+		if samePTR(rec.PTR, want) == negate {
+			return fmt.Errorf("unexpected PTR %q for %s", rec.PTR, ip)
+		}
+		return nil
+	}
+}
+
+func TestAccReverseDNSResource_basic(t *testing.T) {
+	e := newAccEnv(t)
+	server := testAccServerConfig(map[string]any{"hostname": "acc-mail-01"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, server, testAccReverseDNSConfig("mail.example.com.")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(testAccReverseDNSAddr, "id", testAccServerAddr, "ip_address"),
+					resource.TestCheckResourceAttrPair(testAccReverseDNSAddr, "server_id", testAccServerAddr, "id"),
+					e.checkPTR("mail.example.com", false),
+				),
+			},
+			// A new name is applied in place.
+			{
+				Config: e.config(nil, server, testAccReverseDNSConfig("relay.example.com")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccReverseDNSAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccReverseDNSAddr, "ptr", "relay.example.com"),
+					e.checkPTR("relay.example.com", false),
+				),
+			},
+			{
+				ResourceName:      testAccReverseDNSAddr,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Destroying the record restores the default name.
+			{
+				Config: e.config(nil, server),
+				Check:  e.checkPTR("relay.example.com", true),
+			},
+		},
+	})
+}
+
+func TestAccReverseDNSResource_drift(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	config := e.config(map[string]any{"drift_policy": "adopt", "recreate_on_missing": true},
+		testAccServerConfig(map[string]any{"hostname": "acc-mail-02"}),
+		testAccReverseDNSConfig("mail.example.com"))
+	var ip, serverID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "ip_address", &ip),
+					testAccAttr(testAccServerAddr, "id", &serverID),
+				),
+			},
+			// A PTR changed in the portal is adopted and planned back.
+			{
+				PreConfig:          func() { e.fake.SetReverseDNS(ip, "edited.example.com") },
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPostRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccReverseDNSAddr, plancheck.ResourceActionUpdate),
+				}},
+			},
+			{
+				Config: config,
+				Check:  e.checkPTR("mail.example.com", false),
+			},
+			// The record goes with its server.
+			{
+				PreConfig:          func() { e.fake.Remove(serverID) },
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPostRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccReverseDNSAddr, plancheck.ResourceActionCreate),
+				}},
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &serverID),
+					e.checkPTR("mail.example.com", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccReverseDNSResource_validation(t *testing.T) {
+	e := newAccEnv(t)
+	record := func(ip any, ptr string) string {
+		return testAccResource("rackdog_reverse_dns", "test", map[string]any{"ip_address": ip, "ptr": ptr})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      e.config(nil, record("not-an-ip", "mail.example.com")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid IP address`),
+			},
+			{
+				Config:      e.config(nil, record("198.51.100.7", "mail")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid PTR record`),
+			},
+			// An address outside the account fails at plan time.
+			{
+				Config:      e.config(nil, record("198.51.100.7", "mail.example.com")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`IP address not in account`),
+			},
+			// An address not known until apply, such as a new server's, is
+			// left to the API.
+			{
+				Config:             e.config(nil, testAccServerConfig(nil), testAccReverseDNSConfig("mail.example.com")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestReverseDNSResource_Schema(t *testing.T) {
+	r := NewReverseDNSResource()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "ip_address", "ptr", "server_id"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+		}
+	}
+
+	mresp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "rackdog"}, mresp)
+	if mresp.TypeName != "rackdog_reverse_dns" {
+		t.Errorf("expected TypeName 'rackdog_reverse_dns', got %s", mresp.TypeName)
+	}
+}
+
+func TestCheckPTR(t *testing.T) {
+	tests := []struct {
+		name    string
+		ptr     string
+		wantErr bool
+	}{
+		{"fqdn", "mail.example.com", false},
+		{"trailing dot", "mail.example.com.", false},
+		{"single label", "mail", true},
+		{"underscore", "mail_relay.example.com", true},
+		{"empty label", "mail..example.com", true},
+		{"spaces", "mail example.com", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPTR(tt.ptr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkPTR(%q) error = %v, wantErr %v", tt.ptr, err, tt.wantErr)
+			}
+		})
+	}
+
+	if !samePTR("Mail.Example.com.", "mail.example.com") || samePTR("mail.example.com", "mx.example.com") {
+		t.Fatal("samePTR should ignore case and a trailing dot only")
+	}
+}
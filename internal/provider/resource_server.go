@@ -2,34 +2,128 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"net/http"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type serverResource struct {
-	client *Client
-	cfg    resolvedConfig
+	client  *Client
+	catalog *catalog
+	cfg     resolvedConfig
 }
 
+var (
+	_ resource.ResourceWithImportState    = &serverResource{}
+	_ resource.ResourceWithValidateConfig = &serverResource{}
+	_ resource.ResourceWithModifyPlan     = &serverResource{}
+)
+
+var hostnameRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// serverFieldPaths maps allocate request fields to schema attributes so
+// API validation errors point at the offending argument.
+var serverFieldPaths = map[string]path.Path{
+	"planId":     path.Root("plan_id"),
+	"locationId": path.Root("location_id"),
+	"osId":       path.Root("os_id"),
+	"raid":       path.Root("raid"),
+	"hostname":   path.Root("hostname"),
+	"sshKeyIds":  path.Root("ssh_key_ids"),
+	"userData":   path.Root("user_data"),
+	"tags":       path.Root("tags"),
+}
+
+const (
+	// orderClockSkew is how much earlier than the allocate request the API
+	// may date the server it created.
+	orderClockSkew = time.Minute
+	// orderReplayWindow is how old the server of a replayed order may be and
+	// still be adopted. An apply re-run after losing the response comes
+	// soon after; an older server belongs to another resource, such as the
+	// one a create_before_destroy replacement is replacing.
+	orderReplayWindow = 15 * time.Minute
+)
+
+// os_change_strategy and user_data_change_strategy values.
+const (
+	osChangeReplace   = "replace"
+	osChangeReinstall = "reinstall"
+)
+
 func NewServerResource() resource.Resource { return &serverResource{} }
 
 type serverModel struct {
-	ID         types.String `tfsdk:"id"`
-	PlanID     types.Int64  `tfsdk:"plan_id"`
-	LocationID types.Int64  `tfsdk:"location_id"`
-	OSID       types.Int64  `tfsdk:"os_id"`
-	Raid       types.Int64  `tfsdk:"raid"`
-	Hostname   types.String `tfsdk:"hostname"`
-	IPAddress  types.String `tfsdk:"ip_address"`
-	Status     types.String `tfsdk:"status"`
+	ID               types.String  `tfsdk:"id"`
+	PlanID           types.Int64   `tfsdk:"plan_id"`
+	LocationID       types.Int64   `tfsdk:"location_id"`
+	OSID             types.Int64   `tfsdk:"os_id"`
+	Raid             types.Int64   `tfsdk:"raid"`
+	Hostname         types.String  `tfsdk:"hostname"`
+	SSHKeyIDs        types.Set     `tfsdk:"ssh_key_ids"`
+	UserData         types.String  `tfsdk:"user_data"`
+	UserDataBase64   types.String  `tfsdk:"user_data_base64"`
+	UserDataHash     types.String  `tfsdk:"user_data_hash"`
+	IdempotencyKey   types.String  `tfsdk:"idempotency_key"`
+	UserDataStrategy types.String  `tfsdk:"user_data_change_strategy"`
+	Tags             types.Map     `tfsdk:"tags"`
+	TagsAll          types.Map     `tfsdk:"tags_all"`
+	IPAddress        types.String  `tfsdk:"ip_address"`
+	Status           types.String  `tfsdk:"status"`
+	OSChangeStrategy types.String  `tfsdk:"os_change_strategy"`
+	MonthlyPrice     types.Float64 `tfsdk:"monthly_price"`
+	PowerState       types.String  `tfsdk:"power_state"`
+	RebootTrigger    types.String  `tfsdk:"reboot_trigger"`
+	DriftPolicy      types.String  `tfsdk:"drift_policy"`
+	Timeouts         types.Object  `tfsdk:"timeouts"`
+}
+
+// refreshComputed copies the API-owned attributes of s into m. A planned
+// hostname is kept: a remote rename is drift for Read to report, and
+// changing it here would contradict the plan.
+func (m *serverModel) refreshComputed(s *Server) {
+	if s.Hostname != nil && (m.Hostname.IsUnknown() || m.Hostname.IsNull()) {
+		m.Hostname = types.StringValue(*s.Hostname)
+	}
+	m.IPAddress = types.StringValue(s.IPAddress)
+	m.Status = types.StringValue(powerStatus(s))
+	// A price planned from the catalog is kept until the next refresh so the
+	// apply result matches the plan.
+	if m.MonthlyPrice.IsUnknown() {
+		m.MonthlyPrice = types.Float64Null()
+		if price, ok := parseMonthlyPrice(s.MonthlyPrice); ok {
+			m.MonthlyPrice = types.Float64Value(price)
+		}
+	}
+	if ps := powerStateOf(powerStatus(s)); ps != "" {
+		m.PowerState = types.StringValue(ps)
+	} else if m.PowerState.IsUnknown() {
+		m.PowerState = types.StringNull()
+	}
 }
 
 func (r *serverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,7 +134,18 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	resp.Schema = schema.Schema{
 		Description: "Manages Rackdog servers.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"idempotency_key": schema.StringAttribute{
+				Computed: true,
+				Description: "Idempotency-Key the server was ordered with. It is derived from the configuration, so an apply " +
+					"retried after the API's response was lost gets the original server back instead of ordering another. A server " +
+					"that is gone or older than 15 minutes is ordered again under a fresh key.",
+			},
 			"plan_id": schema.Int64Attribute{
 				Required: true,
 				PlanModifiers: []planmodifier.Int64{
@@ -54,11 +159,20 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"os_id": schema.Int64Attribute{
-				Required: true,
+				Required:    true,
+				Description: "Operating system ID. Changing it replaces the server unless os_change_strategy is \"reinstall\".",
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(requiresReplaceUnlessReinstall,
+						"Replaces the server unless os_change_strategy is \"reinstall\".",
+						"Replaces the server unless `os_change_strategy` is `\"reinstall\"`."),
 				},
 			},
+			"os_change_strategy": schema.StringAttribute{
+				Optional: true,
+				Description: "How an os_id change is applied: \"replace\" (default) orders a new server, " +
+					"\"reinstall\" reinstalls the same server and keeps its hardware and IP address.",
+				Validators: []validator.String{stringOneOf(osChangeReplace, osChangeReinstall)},
+			},
 			"raid": schema.Int64Attribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.Int64{
@@ -66,19 +180,97 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"hostname": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Server hostname. Changing it renames the server in place.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_key_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "IDs of rackdog_ssh_key resources to install for root at provisioning time. Changing it replaces the server.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_data": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Description: "cloud-init user data, e.g. a #cloud-config document or a shell script. Write-only: it is never " +
+					"stored in state, and changes are detected through user_data_hash. Conflicts with user_data_base64.",
+			},
+			"user_data_base64": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Description: "Base64-encoded user data, for binary or gzip-compressed payloads such as base64gzip(). " +
+					"Write-only, like user_data. Conflicts with user_data.",
+			},
+			"user_data_hash": schema.StringAttribute{
 				Computed: true,
+				Description: "SHA-256 of the decoded user data, as \"sha256:<hex>\". Null when no user data is set. " +
+					"A change replaces the server unless user_data_change_strategy is \"reinstall\". " +
+					"Without a prior hash, such as after an import, the hash is only recorded.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"user_data_change_strategy": schema.StringAttribute{
+				Optional: true,
+				Description: "How a user data change is applied: \"replace\" (default) orders a new server, " +
+					"\"reinstall\" reinstalls the same server with the new user data.",
+				Validators: []validator.String{stringOneOf(osChangeReplace, osChangeReinstall)},
+			},
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags on the server, e.g. owning team or cost centre. Changed in place.",
+			},
+			"tags_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "All tags on the server: the provider's default_tags merged with tags, which take precedence.",
+			},
 			"ip_address": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"status": schema.StringAttribute{Computed: true},
+			"monthly_price": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Monthly price of the server. Known at plan time from the plan's price in the chosen location.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"power_state": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Desired power state, \"on\" or \"off\". Changing it powers the server on or off in place.",
+				Validators:  []validator.String{stringOneOf(PowerActionOn, PowerActionOff)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reboot_trigger": schema.StringAttribute{
+				Optional:    true,
+				Description: "Arbitrary value; changing it reboots the server. Setting it where it had no value, such as after an import, only records it.",
+			},
+			"drift_policy": schema.StringAttribute{
+				Optional: true,
+				Description: "What to do when the server was changed outside Terraform: \"error\", \"warn\" or \"adopt\". " +
+					"Overrides the provider-level drift_policy once applied; refresh uses the value in state, so setting it " +
+					"cannot rescue a refresh that is already failing. Use the provider-level drift_policy for that.",
+				Validators: []validator.String{stringOneOf(driftPolicies...)},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock("create", "update"),
 		},
 	}
 }
@@ -89,9 +281,183 @@ func (r *serverResource) Configure(_ context.Context, req resource.ConfigureRequ
 	}
 	pd := req.ProviderData.(*ProviderData)
 	r.client = pd.Client
+	r.catalog = pd.Catalog
 	r.cfg = pd.Cfg
 }
 
+// ValidateConfig runs the checks that need no API access.
+func (r *serverResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config serverModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if h := config.Hostname; !h.IsNull() && !h.IsUnknown() {
+		if len(h.ValueString()) > 253 || !hostnameRe.MatchString(h.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("hostname"), "Invalid hostname",
+				fmt.Sprintf("%q is not a valid hostname: use letters, digits and hyphens in dot-separated labels of at most 63 characters.", h.ValueString()))
+		}
+	}
+	if rd := config.Raid; !rd.IsNull() && !rd.IsUnknown() && rd.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("raid"), "Invalid RAID level", "raid must not be negative.")
+	}
+	if tags, _ := knownTags(config.Tags); tags != nil {
+		if err := checkTags(tags); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tags"), "Invalid tags", err.Error())
+		}
+	}
+	if _, _, err := config.userData(); err != nil {
+		attr := path.Root("user_data_base64")
+		if config.UserDataBase64.IsNull() {
+			attr = path.Root("user_data")
+		}
+		resp.Diagnostics.AddAttributeError(attr, "Invalid user data", err.Error())
+	}
+}
+
+// ModifyPlan checks the plan/location/OS/RAID combination against the
+// catalog at plan time, so an unsellable combination fails `terraform plan`
+// instead of the allocate call halfway through an apply. Only known values
+// that are new or changed are checked.
+func (r *serverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan serverModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(plan.configUserData(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state serverModel
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Invalid user data is reported by ValidateConfig. The user data itself
+	// is write-only, so its hash is what plans a change.
+	if data, known, err := plan.userData(); err == nil {
+		hash := types.StringUnknown()
+		if known {
+			hash = userDataHashValue(data)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_data_hash"), hash)...)
+		// Without a prior hash, after an import or on a server ordered
+		// without user data, the hash is only recorded: user data only
+		// runs on first boot.
+		if !creating && !state.UserDataHash.IsNull() && !hash.Equal(state.UserDataHash) && !reinstallChosen(ctx, req.Plan, "user_data_change_strategy", &resp.Diagnostics) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_data_hash"))
+		}
+	}
+
+	tagsAll := types.MapUnknown(types.StringType)
+	if tags, known := knownTags(plan.Tags); known && !r.cfg.DefaultTagsUnknown {
+		tagsAll = tagsAllValue(mergeTags(r.cfg.DefaultTags, tags))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+
+	// A new server's key is only settled by the order itself: an earlier
+	// order with the same key may have to be ordered again under a fresh one.
+	key := state.IdempotencyKey
+	if creating {
+		key = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("idempotency_key"), key)...)
+
+	if r.client == nil {
+		return
+	}
+	changed := func(planned, prior types.Int64) bool {
+		return !planned.IsUnknown() && !planned.IsNull() && (creating || !planned.Equal(prior))
+	}
+
+	planKnown := !plan.PlanID.IsUnknown() && !plan.LocationID.IsUnknown()
+	if planKnown && (changed(plan.PlanID, state.PlanID) || changed(plan.LocationID, state.LocationID)) {
+		price := types.Float64Unknown()
+		if p, ok := r.validatePlanLocation(ctx, plan, &resp.Diagnostics); ok {
+			price = types.Float64Value(p)
+			if maxSpend := r.cfg.MaxMonthlySpendPerServer; maxSpend > 0 && p > maxSpend {
+				resp.Diagnostics.AddAttributeError(path.Root("plan_id"), "Server over budget",
+					fmt.Sprintf("Plan %d costs %.2f per month in location %d, above the provider's max_monthly_spend_per_server of %.2f.",
+						plan.PlanID.ValueInt64(), p, plan.LocationID.ValueInt64(), maxSpend))
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monthly_price"), price)...)
+	}
+
+	if changed(plan.OSID, state.OSID) {
+		r.validateOS(ctx, plan, &resp.Diagnostics)
+	}
+
+	if !plan.PlanID.IsUnknown() && (changed(plan.Raid, state.Raid) || (!plan.Raid.IsNull() && changed(plan.PlanID, state.PlanID))) {
+		r.validateRaid(ctx, plan, &resp.Diagnostics)
+	}
+}
+
+// validatePlanLocation checks that the plan exists and is sold in the
+// location, and returns its monthly price there.
+func (r *serverResource) validatePlanLocation(ctx context.Context, plan serverModel, diags *diag.Diagnostics) (float64, bool) {
+	plans, err := r.catalog.ListPlans(ctx, "")
+	if err != nil {
+		diags.AddWarning("Could not validate plan_id", "Listing plans failed, the combination will be checked on apply: "+err.Error())
+		return 0, false
+	}
+
+	planID, locationID := int(plan.PlanID.ValueInt64()), int(plan.LocationID.ValueInt64())
+	i := slices.IndexFunc(plans, func(p Plan) bool { return p.ID == planID })
+	if i < 0 {
+		diags.AddAttributeError(path.Root("plan_id"), "Unknown plan", fmt.Sprintf("Plan %d does not exist.", planID))
+		return 0, false
+	}
+	p := plans[i]
+	if j := slices.IndexFunc(p.Locations, func(l PlanLocation) bool { return l.ID == locationID }); j >= 0 {
+		return p.Locations[j].MonthlyPrice, true
+	}
+	sold := make([]string, len(p.Locations))
+	for j, l := range p.Locations {
+		sold[j] = fmt.Sprintf("%d (%s)", l.ID, l.Keyword)
+	}
+	diags.AddAttributeError(path.Root("location_id"), "Plan not available in location",
+		fmt.Sprintf("Plan %d (%s) is not sold in location %d. Available locations: %s.",
+			p.ID, p.Name, locationID, strings.Join(sold, ", ")))
+	return 0, false
+}
+
+func (r *serverResource) validateOS(ctx context.Context, plan serverModel, diags *diag.Diagnostics) {
+	osList, err := r.catalog.ListOperatingSystems(ctx)
+	if err != nil {
+		diags.AddWarning("Could not validate os_id", "Listing operating systems failed, os_id will be checked on apply: "+err.Error())
+		return
+	}
+	osID := int(plan.OSID.ValueInt64())
+	if !slices.ContainsFunc(osList, func(o ServerOS) bool { return o.ID == osID }) {
+		diags.AddAttributeError(path.Root("os_id"), "Unknown operating system",
+			fmt.Sprintf("Operating system %d is not in the Rackdog catalog; see the rackdog_operating_systems data source.", osID))
+	}
+}
+
+func (r *serverResource) validateRaid(ctx context.Context, plan serverModel, diags *diag.Diagnostics) {
+	_, err := r.client.CheckRaid(ctx, int(plan.Raid.ValueInt64()), int(plan.PlanID.ValueInt64()))
+	if err == nil {
+		return
+	}
+	// The check endpoint answers an unsupported level with success=false
+	// or a 4xx; anything else means we could not ask.
+	if ae, ok := asAPIError(err); ok && ae.Status < 500 {
+		diags.AddAttributeError(path.Root("raid"), "Invalid RAID for plan",
+			fmt.Sprintf("RAID %d is not available for plan %d: %s", plan.Raid.ValueInt64(), plan.PlanID.ValueInt64(), err))
+		return
+	}
+	diags.AddWarning("Could not validate raid", "RAID check failed, raid will be checked on apply: "+err.Error())
+}
+
 func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
@@ -100,6 +466,7 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	var plan serverModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(plan.configUserData(ctx, req.Config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -117,25 +484,36 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
-	in := &CreateServerRequest{
-		PlanID:     int(plan.PlanID.ValueInt64()),
-		LocationID: int(plan.LocationID.ValueInt64()),
-		OSID:       int(plan.OSID.ValueInt64()),
+	// An unset hostname is left to the API.
+	if plan.Hostname.IsUnknown() {
+		plan.Hostname = types.StringNull()
 	}
-	if !plan.Raid.IsNull() && !plan.Raid.IsUnknown() {
-		rv := int(plan.Raid.ValueInt64())
-		in.Raid = &rv
+	in, known, diags := plan.createRequest(ctx, r.cfg.DefaultTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !plan.Hostname.IsNull() && !plan.Hostname.IsUnknown() {
-		h := plan.Hostname.ValueString()
-		in.Hostname = &h
+	if !known {
+		resp.Diagnostics.AddError("Create failed", "The planned server still has unknown attributes.")
+		return
 	}
+	userData, _, _ := plan.userData() // checked by createRequest
+	plan.UserDataHash = userDataHashValue(userData)
+	plan.TagsAll = tagsAllValue(in.Tags)
 
-	created, err := r.client.CreateServer(ctx, in)
-	if err != nil {
-		resp.Diagnostics.AddError("Create failed", err.Error())
+	in.IdempotencyKey = orderKey(in)
+	orderedAt := time.Now()
+	created, err := r.order(ctx, in, orderedAt, &resp.Diagnostics)
+	if created != nil && r.staleOrder(ctx, created.ID, orderedAt) {
+		tflog.Info(ctx, "Idempotency key replayed an earlier order, ordering again", map[string]any{"id": created.ID})
+		in.IdempotencyKey = newRequestID()
+		created, err = r.order(ctx, in, time.Now(), &resp.Diagnostics)
+	}
+	if created == nil {
+		appendAPIError(&resp.Diagnostics, "Create failed", err, serverFieldPaths)
 		return
 	}
+	plan.IdempotencyKey = types.StringValue(in.IdempotencyKey)
 
 	plan.ID = types.StringValue(created.ID)
 	if created.Hostname != nil {
@@ -143,13 +521,52 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	plan.IPAddress = types.StringValue(created.IPAddress)
 	plan.Status = types.StringNull()
+	if created.PowerStatus != nil {
+		plan.Status = types.StringValue(*created.PowerStatus)
+	}
 
+	// Record the server before waiting so a failed or stalled provision is
+	// kept in state (tainted) instead of leaking billable hardware.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	//r.Read(ctx, resource.ReadRequest{State: resp.State}, &resp.ReadResponse)
+	timeout, diags := timeoutFor(ctx, plan.Timeouts, "create", defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s, err := waitForServerStatus(ctx, r.client, created.ID, timeout, isReadyPowerStatus)
+	if err != nil {
+		var fe *ServerFailedError
+		var te *WaitTimeoutError
+		switch {
+		case errors.As(err, &fe):
+			resp.Diagnostics.AddError("Server provisioning failed",
+				fmt.Sprintf("Server %s reported status %q while provisioning. It is kept in state as tainted and will be replaced on the next apply.",
+					fe.ID, fe.Status))
+		case errors.As(err, &te):
+			resp.Diagnostics.AddError("Timed out waiting for server",
+				fmt.Sprintf("Server %s was not ready after %s (last status %q). It is kept in state as tainted; "+
+					"check it in the Rackdog portal or raise timeouts.create.", te.ID, te.Timeout, te.LastStatus))
+		default:
+			resp.Diagnostics.AddError("Waiting for server failed", err.Error())
+		}
+		return
+	}
+
+	if plan.PowerState.ValueString() == PowerActionOff {
+		s, err = r.setPowerState(ctx, created.ID, PowerActionOff, timeout)
+		if err != nil {
+			resp.Diagnostics.AddError("Powering off server failed", err.Error())
+			return
+		}
+	}
+	plan.refreshComputed(s)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -166,8 +583,7 @@ func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	s, err := r.client.GetServer(ctx, state.ID.ValueString())
 	if err != nil {
-		var he *HTTPError
-		if errors.As(err, &he) && he.Status == http.StatusNotFound {
+		if IsNotFound(err) {
 			if !r.cfg.RecreateOnMissing {
 				resp.Diagnostics.AddError(
 					"Server deleted outside Terraform",
@@ -187,69 +603,446 @@ func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	policy := r.cfg.DriftPolicy
+	if !state.DriftPolicy.IsNull() && !state.DriftPolicy.IsUnknown() {
+		policy = state.DriftPolicy.ValueString()
+	}
+	drifts := append(serverDrift(&state, s), tagsDrift(&state, s, r.cfg.DefaultTags)...)
+	if !applyDrift(&resp.Diagnostics, policy, drifts) {
+		return
+	}
+
+	// After an import only the ID is known; backfill the configuration
+	// attributes from the API so the next plan compares against reality.
+	if state.PlanID.IsNull() {
+		if s.Raid != nil {
+			state.Raid = types.Int64Value(int64(*s.Raid))
+		}
+		state.PlanID = types.Int64Value(int64(s.Plan.ID))
+		state.Tags = tagsValue(withoutDefaultTags(s.Tags, r.cfg.DefaultTags))
+	}
+	// tags_all is null after an import, and for untagged servers created
+	// by earlier versions. Record it now so later changes are drift.
+	if state.TagsAll.IsNull() {
+		state.TagsAll = tagsAllValue(s.Tags)
+	}
+	if state.LocationID.IsNull() {
+		state.LocationID = types.Int64Value(int64(s.Location.ID))
+	}
+	if state.OSID.IsNull() && s.ServerOS != nil {
+		state.OSID = types.Int64Value(int64(s.ServerOS.ID))
+	}
+
+	if state.Hostname.IsNull() && s.Hostname != nil {
+		state.Hostname = types.StringValue(*s.Hostname)
+	}
+	if state.SSHKeyIDs.IsNull() && len(s.SSHKeyIDs) > 0 {
+		ids, d := types.SetValueFrom(ctx, types.StringType, s.SSHKeyIDs)
+		resp.Diagnostics.Append(d...)
+		state.SSHKeyIDs = ids
+	}
+
+	state.IPAddress = types.StringValue(s.IPAddress)
+	if price, ok := parseMonthlyPrice(s.MonthlyPrice); ok {
+		state.MonthlyPrice = types.Float64Value(price)
+	}
+	if s.PowerStatus != nil {
+		state.Status = types.StringValue(*s.PowerStatus)
+		if ps := powerStateOf(*s.PowerStatus); ps != "" {
+			state.PowerState = types.StringValue(ps)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// serverDrift lists the configuration attributes whose remote value no
+// longer matches state. Attributes not yet known in state (e.g. right
+// after an import) are backfilled by Read instead.
+func serverDrift(state *serverModel, s *Server) []drift {
+	var out []drift
+
 	if !state.Hostname.IsNull() && s.Hostname != nil && state.Hostname.ValueString() != *s.Hostname {
-		resp.Diagnostics.AddError(
-			"Out-of-band change detected (hostname)",
-			fmt.Sprintf("Remote hostname is %q but state expected %q. This likely happened outside Terraform (portal/api). "+
-				"Please reconcile: either update your config to match, import the correct resource, or replace this server.",
-				*s.Hostname, state.Hostname.ValueString()),
-		)
+		remote := *s.Hostname
+		out = append(out, drift{
+			attr:   "hostname",
+			state:  fmt.Sprintf("%q", state.Hostname.ValueString()),
+			remote: fmt.Sprintf("%q", remote),
+			adopt:  func() { state.Hostname = types.StringValue(remote) },
+		})
+	}
+
+	int64Drift := func(attr string, current types.Int64, remote int64, set func(types.Int64)) {
+		if current.IsNull() || current.IsUnknown() || current.ValueInt64() == 0 || current.ValueInt64() == remote {
+			return
+		}
+		out = append(out, drift{
+			attr:   attr,
+			state:  fmt.Sprintf("%d", current.ValueInt64()),
+			remote: fmt.Sprintf("%d", remote),
+			adopt:  func() { set(types.Int64Value(remote)) },
+		})
+	}
+
+	if s.Plan.ID != 0 {
+		int64Drift("plan_id", state.PlanID, int64(s.Plan.ID), func(v types.Int64) { state.PlanID = v })
+	}
+	if s.Location.ID != 0 {
+		int64Drift("location_id", state.LocationID, int64(s.Location.ID), func(v types.Int64) { state.LocationID = v })
+	}
+	if s.ServerOS != nil {
+		int64Drift("os_id", state.OSID, int64(s.ServerOS.ID), func(v types.Int64) { state.OSID = v })
+	}
+	if s.Raid != nil && !state.Raid.IsNull() && !state.Raid.IsUnknown() && state.Raid.ValueInt64() != int64(*s.Raid) {
+		remote := int64(*s.Raid)
+		out = append(out, drift{
+			attr:   "raid",
+			state:  fmt.Sprintf("%d", state.Raid.ValueInt64()),
+			remote: fmt.Sprintf("%d", remote),
+			adopt:  func() { state.Raid = types.Int64Value(remote) },
+		})
+	}
+
+	return out
+}
+
+// tagsDrift reports tags changed outside Terraform. Adopting them keeps
+// tags that match default_tags inherited rather than copying them into
+// tags. Servers whose tags_all was never recorded (null) are skipped; an
+// untagged server records an empty map.
+func tagsDrift(state *serverModel, s *Server, defaults map[string]string) []drift {
+	current, known := knownTags(state.TagsAll)
+	if state.TagsAll.IsNull() || !known || maps.Equal(current, s.Tags) {
+		return nil
+	}
+	remote := s.Tags
+	return []drift{{
+		attr:   "tags",
+		state:  formatTags(current),
+		remote: formatTags(remote),
+		adopt: func() {
+			state.TagsAll = tagsAllValue(remote)
+			state.Tags = tagsValue(withoutDefaultTags(remote, defaults))
+		},
+	}}
+}
+
+func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var plan, state serverModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(plan.configUserData(ctx, req.Config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.ID = state.ID
 
-	if s.Plan.ID != 0 && state.PlanID.ValueInt64() != 0 {
-		if state.PlanID.ValueInt64() != int64(s.Plan.ID) {
-			resp.Diagnostics.AddError(
-				"Out-of-band change detected (plan_id)",
-				"Remote plan differs from state; reconcile manually and re-run.",
-			)
+	var upd UpdateServerRequest
+	if !plan.Hostname.IsUnknown() && !plan.Hostname.IsNull() && !plan.Hostname.Equal(state.Hostname) {
+		h := plan.Hostname.ValueString()
+		upd.Hostname = &h
+	}
+	tags, _ := knownTags(plan.Tags)
+	tagsAll := mergeTags(r.cfg.DefaultTags, tags)
+	if priorAll, _ := knownTags(state.TagsAll); !maps.Equal(tagsAll, priorAll) {
+		upd.Tags = tagsAll
+		if upd.Tags == nil {
+			upd.Tags = map[string]string{}
+		}
+	}
+	plan.TagsAll = tagsAllValue(tagsAll)
+	if upd.Hostname != nil || upd.Tags != nil {
+		if _, err := r.client.UpdateServer(ctx, state.ID.ValueString(), &upd); err != nil {
+			appendAPIError(&resp.Diagnostics, "Update failed", err, serverFieldPaths)
 			return
 		}
 	}
 
-	if s.Location.ID != 0 && state.LocationID.ValueInt64() != 0 {
-		if state.LocationID.ValueInt64() != int64(s.Location.ID) {
-			resp.Diagnostics.AddError(
-				"Out-of-band change detected (location_id)",
-				"Remote location differs from state; reconcile manually and re-run.",
-			)
+	timeout, diags := timeoutFor(ctx, plan.Timeouts, "update", defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, _, err := plan.userData()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data"), "Invalid user data", err.Error())
+		return
+	}
+	plan.UserDataHash = userDataHashValue(userData)
+
+	// Plan-time replacement handles these changes unless the matching
+	// change strategy is "reinstall". A hash without a prior value is only
+	// recorded, as in ModifyPlan.
+	userDataChanged := !state.UserDataHash.IsNull() && !plan.UserDataHash.Equal(state.UserDataHash)
+	if !plan.OSID.Equal(state.OSID) || userDataChanged {
+		in := &ReinstallServerRequest{OSID: int(plan.OSID.ValueInt64())}
+		if !plan.Raid.IsNull() && !plan.Raid.IsUnknown() {
+			rv := int(plan.Raid.ValueInt64())
+			in.Raid = &rv
+		}
+		// Keys and user data are only applied on first boot, so a
+		// reinstall needs them again.
+		resp.Diagnostics.Append(plan.SSHKeyIDs.ElementsAs(ctx, &in.SSHKeyIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if userData != nil {
+			in.UserData = base64.StdEncoding.EncodeToString(userData)
+		}
+		if err := r.client.ReinstallServer(ctx, state.ID.ValueString(), in); err != nil {
+			appendAPIError(&resp.Diagnostics, "Reinstall failed", err, serverFieldPaths)
+			return
+		}
+		if _, err := waitForServerAction(ctx, r.client, state.ID.ValueString(), timeout, isReadyPowerStatus); err != nil &&
+			!warnNotObserved(&resp.Diagnostics, "Reinstall not observed", err) {
+			resp.Diagnostics.AddError("Waiting for reinstall failed", err.Error())
 			return
 		}
+		// A reinstalled server comes back powered on.
+		state.PowerState = types.StringValue(PowerActionOn)
 	}
 
-	if s.ServerOS != nil && state.OSID.ValueInt64() != 0 {
-		if int64(s.ServerOS.ID) != state.OSID.ValueInt64() {
-			resp.Diagnostics.AddError(
-				"Out-of-band change detected (os_id)",
-				"Remote OS differs from state; reconcile manually and re-run.",
-			)
+	wantPower := plan.PowerState.ValueString()
+	powerChanged := !plan.PowerState.IsUnknown() && !plan.PowerState.IsNull() && !plan.PowerState.Equal(state.PowerState)
+	if powerChanged {
+		if _, err := r.setPowerState(ctx, state.ID.ValueString(), wantPower, timeout); err != nil {
+			resp.Diagnostics.AddError("Changing power state failed", err.Error())
 			return
 		}
 	}
 
-	if s.Raid != nil && !state.Raid.IsNull() && !state.Raid.IsUnknown() {
-		if int(state.Raid.ValueInt64()) != *s.Raid {
-			resp.Diagnostics.AddError(
-				"Out-of-band change detected (raid)",
-				"Remote RAID differs from state; reconcile manually and re-run.",
-			)
+	// A reboot of a server that is (or just went) off would power it on.
+	// A trigger without a prior value, after an import or when the
+	// attribute is first set, is only recorded.
+	rebootTriggered := !state.RebootTrigger.IsNull() && !plan.RebootTrigger.Equal(state.RebootTrigger)
+	if rebootTriggered && !powerChanged && wantPower != PowerActionOff {
+		if _, err := r.setPowerState(ctx, state.ID.ValueString(), PowerActionReboot, timeout); err != nil &&
+			!warnNotObserved(&resp.Diagnostics, "Reboot not observed", err) {
+			resp.Diagnostics.AddError("Reboot failed", err.Error())
 			return
 		}
 	}
 
-	state.IPAddress = types.StringValue(s.IPAddress)
-	if s.Hostname != nil {
-		state.Hostname = types.StringValue(*s.Hostname)
+	s, err := r.client.GetServer(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Read after update failed", err.Error())
+		return
 	}
-	if s.PowerStatus != nil {
-		state.Status = types.StringValue(*s.PowerStatus)
+	plan.refreshComputed(s)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// createRequest builds the allocate request for m, without its
+// idempotency key. known is false while a field is unknown, as at plan time
+// when the configuration refers to resources not created yet.
+func (m *serverModel) createRequest(ctx context.Context, defaultTags map[string]string) (in *CreateServerRequest, known bool, diags diag.Diagnostics) {
+	if m.PlanID.IsUnknown() || m.LocationID.IsUnknown() || m.OSID.IsUnknown() || m.Raid.IsUnknown() ||
+		m.Hostname.IsUnknown() || m.SSHKeyIDs.IsUnknown() ||
+		slices.ContainsFunc(m.SSHKeyIDs.Elements(), attr.Value.IsUnknown) {
+		return nil, false, nil
+	}
+	userData, known, err := m.userData()
+	if err != nil {
+		diags.AddAttributeError(path.Root("user_data"), "Invalid user data", err.Error())
+		return nil, false, diags
+	}
+	tags, tagsKnown := knownTags(m.Tags)
+	if !known || !tagsKnown {
+		return nil, false, nil
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	in = &CreateServerRequest{
+		PlanID:     int(m.PlanID.ValueInt64()),
+		LocationID: int(m.LocationID.ValueInt64()),
+		OSID:       int(m.OSID.ValueInt64()),
+		Tags:       mergeTags(defaultTags, tags),
+	}
+	if !m.Raid.IsNull() {
+		rv := int(m.Raid.ValueInt64())
+		in.Raid = &rv
+	}
+	if !m.Hostname.IsNull() {
+		h := m.Hostname.ValueString()
+		in.Hostname = &h
+	}
+	diags.Append(m.SSHKeyIDs.ElementsAs(ctx, &in.SSHKeyIDs, false)...)
+	if userData != nil {
+		in.UserData = base64.StdEncoding.EncodeToString(userData)
+	}
+	return in, true, diags
 }
 
-func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddWarning("No Update implemented", "Rackdog servers cannot be updated.")
+// orderKey derives the Idempotency-Key of an allocate from the request. An
+// apply retried after a lost response sends the same key, so the API
+// returns the original order instead of placing another. Servers without a
+// hostname get a random key: identical ones, e.g. under count, would
+// otherwise share an order.
+func orderKey(in *CreateServerRequest) string {
+	if in.Hostname == nil {
+		return newRequestID()
+	}
+	req := *in
+	req.SSHKeyIDs = slices.Sorted(slices.Values(in.SSHKeyIDs))
+	b, _ := json.Marshal(req)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:16])
+}
+
+// order places in, adopting the server it made when the response is lost.
+// It returns nil and the allocate error when nothing was ordered.
+func (r *serverResource) order(ctx context.Context, in *CreateServerRequest, orderedAt time.Time, diags *diag.Diagnostics) (*ServerListItem, error) {
+	created, err := r.client.CreateServer(ctx, in)
+	if err != nil && responseLost(err) {
+		created = r.findLostOrder(ctx, in, orderedAt, diags)
+	}
+	return created, err
+}
+
+// staleOrder reports whether the server an allocate sent at orderedAt
+// returned must not be adopted. Its key replays an earlier order of the
+// same configuration when the server predates orderedAt, and that server
+// may since have been destroyed, be deleting or have failed, or be older
+// than orderReplayWindow and so managed elsewhere. A server that stays
+// missing for orderVisibleTimeout is taken to be gone. Lookup failures are
+// left to the wait that follows.
+func (r *serverResource) staleOrder(ctx context.Context, id string, orderedAt time.Time) bool {
+	s, err := waitForServerStatus(ctx, r.client, id, orderVisibleTimeout, func(string) bool { return true })
+	if err != nil {
+		var te *WaitTimeoutError
+		return errors.As(err, &te)
+	}
+	createdAt, err := time.Parse(time.RFC3339, s.CreatedAt)
+	if err != nil || !createdAt.Before(orderedAt.Add(-orderClockSkew)) {
+		return false
+	}
+	status := powerStatus(s)
+	return isDeletingPowerStatus(status) || isFailedPowerStatus(status) || orderedAt.Sub(createdAt) > orderReplayWindow
+}
+
+// responseLost reports whether err leaves open whether the API acted on the
+// request: the connection failed, or the API did (5xx).
+func responseLost(err error) bool {
+	ae, ok := asAPIError(err)
+	return !ok || ae.Status >= 500
+}
+
+// findLostOrder looks for the server an allocate may have ordered although
+// its response was lost: one created since orderedAt that matches every
+// field of in and is not being deleted. The API never returns user data,
+// so an order carrying it is not adopted and its retry relies on the
+// idempotency key alone. Nothing is adopted when the match is ambiguous.
+// Lookup failures are logged and ignored.
+func (r *serverResource) findLostOrder(ctx context.Context, in *CreateServerRequest, orderedAt time.Time, diags *diag.Diagnostics) *ServerListItem {
+	if in.Hostname == nil || in.UserData != "" {
+		return nil
+	}
+	servers, err := r.client.ListServersByHostname(ctx, *in.Hostname)
+	if err != nil {
+		tflog.Warn(ctx, "Could not check for a server from the lost order", map[string]any{"error": err.Error()})
+		return nil
+	}
+
+	var found []*Server
+	for i := range servers {
+		if s := &servers[i]; madeByOrder(s, in, orderedAt) {
+			found = append(found, s)
+		}
+	}
+	if len(found) != 1 {
+		if len(found) > 1 {
+			tflog.Warn(ctx, "Several servers match the lost order, adopting none", map[string]any{"count": len(found)})
+		}
+		return nil
+	}
+
+	s := found[0]
+	diags.AddWarning("Adopted server from a lost order",
+		fmt.Sprintf("The response to ordering server %q was lost, but server %s was created with the requested configuration "+
+			"since the order was sent. It was adopted instead of ordering another.", *in.Hostname, s.ID))
+	return &ServerListItem{
+		ID:          s.ID,
+		Hostname:    s.Hostname,
+		IPAddress:   s.IPAddress,
+		PowerStatus: s.PowerStatus,
+	}
+}
+
+// madeByOrder reports whether s may be the server in ordered at orderedAt.
+func madeByOrder(s *Server, in *CreateServerRequest, orderedAt time.Time) bool {
+	createdAt, err := time.Parse(time.RFC3339, s.CreatedAt)
+	if err != nil || createdAt.Before(orderedAt.Add(-orderClockSkew)) {
+		return false
+	}
+	if isDeletingPowerStatus(powerStatus(s)) {
+		return false
+	}
+	if s.Plan.ID != in.PlanID || s.Location.ID != in.LocationID || s.ServerOS == nil || s.ServerOS.ID != in.OSID {
+		return false
+	}
+	if in.Raid != nil && (s.Raid == nil || *s.Raid != *in.Raid) {
+		return false
+	}
+	return slices.Equal(slices.Sorted(slices.Values(s.SSHKeyIDs)), slices.Sorted(slices.Values(in.SSHKeyIDs))) &&
+		maps.Equal(s.Tags, in.Tags)
+}
+
+// parseMonthlyPrice reads the API's formatted price, e.g. "$1,299.99".
+func parseMonthlyPrice(v *string) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	cleaned := strings.NewReplacer("$", "", ",", "", "USD", "", " ", "").Replace(*v)
+	price, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, false
+	}
+	return price, true
+}
+
+// requiresReplaceUnlessReinstall replaces the server on an os_id change
+// unless the configuration opts into an in-place reinstall.
+func requiresReplaceUnlessReinstall(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !reinstallChosen(ctx, req.Plan, "os_change_strategy", &resp.Diagnostics)
+}
+
+func reinstallChosen(ctx context.Context, plan tfsdk.Plan, strategyAttr string, diags *diag.Diagnostics) bool {
+	var strategy types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root(strategyAttr), &strategy)...)
+	return strategy.ValueString() == osChangeReinstall
+}
+
+// setPowerState sends a power action and waits until the server reports
+// the matching status. A rebooting server reports ON until the reboot
+// starts, so reboots first wait for it to go down and then to be on again.
+func (r *serverResource) setPowerState(ctx context.Context, id, action string, timeout time.Duration) (*Server, error) {
+	if err := r.client.PowerAction(ctx, id, action); err != nil {
+		return nil, err
+	}
+	switch action {
+	case PowerActionReboot:
+		return waitForServerAction(ctx, r.client, id, timeout, isReadyPowerStatus)
+	case PowerActionOff:
+		return waitForServerStatus(ctx, r.client, id, timeout, isOffPowerStatus)
+	}
+	return waitForServerStatus(ctx, r.client, id, timeout, isReadyPowerStatus)
+}
+
+// warnNotObserved turns an *ActionNotObservedError into a warning and
+// reports whether it did.
+func warnNotObserved(diags *diag.Diagnostics, summary string, err error) bool {
+	var ne *ActionNotObservedError
+	if !errors.As(err, &ne) {
+		return false
+	}
+	diags.AddWarning(summary, "Taking the action as done: "+ne.Error()+".")
+	return true
 }
 
 func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -268,3 +1061,26 @@ func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		resp.Diagnostics.AddError("Delete failed", err.Error())
 	}
 }
+
+// ImportState accepts either a server ID or "hostname:<name>", which is
+// resolved to an ID through the API.
+func (r *serverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if hostname, ok := strings.CutPrefix(req.ID, "hostname:"); ok {
+		if r.client == nil {
+			resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+			return
+		}
+		s, err := r.client.FindServerByHostname(ctx, hostname)
+		if err != nil {
+			resp.Diagnostics.AddError("Import failed", err.Error())
+			return
+		}
+		id = s.ID
+	}
+	if id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected a server ID or \"hostname:<name>\".")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,825 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
+)
+
+const testAccServerAddr = "rackdog_server.test"
+
+// testAccServerConfig returns a rackdog_server.test block ordering the
+// smallest plan. A nil override removes the attribute.
+func testAccServerConfig(overrides map[string]any) string {
+	attrs := map[string]any{
+		"plan_id":     10,
+		"location_id": 1,
+		"os_id":       62,
+		"raid":        1,
+		"hostname":    "acc-web-01",
+	}
+	maps.Copy(attrs, overrides)
+	return testAccResource("rackdog_server", "test", attrs)
+}
+
+// testAccCreateBeforeDestroy is the lifecycle block for servers replaced
+// with create_before_destroy.
+var testAccCreateBeforeDestroy = hclBlock{"create_before_destroy": true}
+
+// testAccAttr copies an attribute of the resource at addr into v, for
+// later steps.
+func testAccAttr(addr, name string, v *string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(addr, name, func(value string) error {
+		*v = value
+		return nil
+	})
+}
+
+// testAccAttrNot fails when the attribute equals any of the values
+// pointed to, such as the ID of a server that should have been replaced.
+func testAccAttrNot(addr, name string, old ...*string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(addr, name, func(value string) error {
+		for _, o := range old {
+			if value == *o {
+				return fmt.Errorf("expected %s to change, still %q", name, value)
+			}
+		}
+		return nil
+	})
+}
+
+// testAccServerSettled fails when the API still has an action queued or
+// in progress for the server, so the apply returned too early.
+func (e *accEnv) testAccServerSettled(addr string) resource.TestCheckFunc {
+	return e.checkFake(addr, func(s fakeapi.ServerRecord) error {
+		if !e.fake.Settled(s.ID) {
+			return fmt.Errorf("apply returned before the action on %s finished", s.ID)
+		}
+		return nil
+	})
+}
+
+// testAccServerReboots fails unless the API accepted want reboots of the
+// server.
+func (e *accEnv) testAccServerReboots(addr string, want int) resource.TestCheckFunc {
+	return e.checkFake(addr, func(s fakeapi.ServerRecord) error {
+		if s.Reboots != want {
+			return fmt.Errorf("expected %d reboots, got %d", want, s.Reboots)
+		}
+		return nil
+	})
+}
+
+func TestAccServerResource_basic(t *testing.T) {
+	e := newAccEnv(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, testAccServerConfig(nil)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttrSet(testAccServerAddr, "ip_address"),
+					resource.TestCheckResourceAttrSet(testAccServerAddr, "idempotency_key"),
+					resource.TestCheckResourceAttr(testAccServerAddr, "power_state", "on"),
+					resource.TestCheckResourceAttr(testAccServerAddr, "monthly_price", "79"),
+				),
+			},
+			{
+				Config:   e.config(nil, testAccServerConfig(nil)),
+				PlanOnly: true,
+			},
+			// Hostname changes in place.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"hostname": "acc-web-02"})),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "hostname", "acc-web-02"),
+				),
+			},
+			// Setting reboot_trigger for the first time only records it.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"hostname": "acc-web-02", "reboot_trigger": "1"})),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServerAddr, "reboot_trigger", "1"),
+					e.testAccServerReboots(testAccServerAddr, 0),
+				),
+			},
+			// Changing reboot_trigger reboots the server and waits for it to
+			// be back on.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"hostname": "acc-web-02", "reboot_trigger": "2"})),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServerAddr, "status", "ON"),
+					e.testAccServerReboots(testAccServerAddr, 1),
+					e.testAccServerSettled(testAccServerAddr),
+				),
+			},
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"hostname": "acc-web-02", "power_state": "off"})),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServerAddr, "power_state", "off"),
+					resource.TestCheckResourceAttr(testAccServerAddr, "status", "OFF"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_replace(t *testing.T) {
+	e := newAccEnv(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, testAccServerConfig(nil)),
+				Check:  testAccAttr(testAccServerAddr, "id", &id),
+			},
+			// By default an OS change replaces the server.
+			{
+				Config:             e.config(nil, testAccServerConfig(map[string]any{"os_id": 70})),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPreRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionReplace),
+				}},
+			},
+			// With os_change_strategy = "reinstall" it is reinstalled in place.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"os_id": 70, "os_change_strategy": "reinstall"})),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "os_id", "70"),
+					e.checkFake(testAccServerAddr, func(s fakeapi.ServerRecord) error {
+						if s.OSID != 70 {
+							return fmt.Errorf("expected the API to report OS 70, got %d", s.OSID)
+						}
+						return nil
+					}),
+					e.testAccServerSettled(testAccServerAddr),
+				),
+			},
+			// A plan change always replaces.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"plan_id": 12, "os_id": 70, "os_change_strategy": "reinstall"})),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionReplace),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "monthly_price", "229"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_userData(t *testing.T) {
+	e := newAccEnv(t)
+	const first, second = "#cloud-config\npackages: [htop]\n", "#cloud-config\npackages: [htop, jq]\n"
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(second))
+	zw.Close()
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      e.config(nil, testAccServerConfig(map[string]any{"user_data": first, "user_data_base64": "YQ=="})),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid user data`),
+			},
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"user_data": first})),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "user_data_hash", userDataHashValue([]byte(first)).ValueString()),
+					// The user data itself is write-only.
+					resource.TestCheckNoResourceAttr(testAccServerAddr, "user_data"),
+					resource.TestCheckNoResourceAttr(testAccServerAddr, "user_data_base64"),
+					e.checkFake(testAccServerAddr, func(s fakeapi.ServerRecord) error {
+						if string(s.UserData) != first {
+							return fmt.Errorf("expected the API to receive the user data, got %q", s.UserData)
+						}
+						return nil
+					}),
+				),
+			},
+			// By default a user data change replaces the server.
+			{
+				Config:             e.config(nil, testAccServerConfig(map[string]any{"user_data": second})),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPreRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionReplace),
+				}},
+			},
+			// With user_data_change_strategy = "reinstall" it is reinstalled
+			// in place, here with gzip-compressed data.
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{
+					"user_data_base64":          base64.StdEncoding.EncodeToString(gz.Bytes()),
+					"user_data_change_strategy": "reinstall",
+				})),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "user_data_hash", userDataHashValue(gz.Bytes()).ValueString()),
+					e.checkFake(testAccServerAddr, func(s fakeapi.ServerRecord) error {
+						if !bytes.Equal(s.UserData, gz.Bytes()) {
+							return fmt.Errorf("expected the reinstall to resend user data, got %q", s.UserData)
+						}
+						return nil
+					}),
+					e.testAccServerSettled(testAccServerAddr),
+				),
+			},
+		},
+	})
+}
+
+// A server without a prior user_data_hash, as after an import, only
+// records the hash of newly configured user data.
+func TestAccServerResource_userDataWithoutPriorHash(t *testing.T) {
+	e := newAccEnv(t)
+	const data = "#cloud-config\npackages: [htop]\n"
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, testAccServerConfig(nil)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "id", &id),
+					resource.TestCheckNoResourceAttr(testAccServerAddr, "user_data_hash"),
+				),
+			},
+			{
+				Config: e.config(nil, testAccServerConfig(map[string]any{"user_data": data})),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "user_data_hash", userDataHashValue([]byte(data)).ValueString()),
+					e.checkFake(testAccServerAddr, func(s fakeapi.ServerRecord) error {
+						if len(s.UserData) != 0 {
+							return fmt.Errorf("expected no reinstall, got user data %q", s.UserData)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   e.config(nil, testAccServerConfig(map[string]any{"user_data": data})),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccServerResource_tags(t *testing.T) {
+	e := newAccEnv(t)
+	defaults := map[string]any{"default_tags": hclBlock{"tags": map[string]string{"env": "acc", "owner": "platform"}}}
+	checkTags := func(want map[string]string) resource.TestCheckFunc {
+		checks := []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(testAccServerAddr, "tags_all.%", fmt.Sprint(len(want))),
+			e.checkFake(testAccServerAddr, func(s fakeapi.ServerRecord) error {
+				if !maps.Equal(s.Tags, want) {
+					return fmt.Errorf("expected the API to hold %v, got %v", want, s.Tags)
+				}
+				return nil
+			}),
+		}
+		for k, v := range want {
+			checks = append(checks, resource.TestCheckResourceAttr(testAccServerAddr, "tags_all."+k, v))
+		}
+		return resource.ComposeAggregateTestCheckFunc(checks...)
+	}
+	var id string
+
+	steps := []resource.TestStep{
+		{
+			Config:      e.config(defaults, testAccServerConfig(map[string]any{"tags": map[string]string{"": "x"}})),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Invalid tags`),
+		},
+		// Resource tags override defaults with the same key.
+		{
+			Config: e.config(defaults, testAccServerConfig(map[string]any{"tags": map[string]string{"app": "web", "env": "test"}})),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				testAccAttr(testAccServerAddr, "id", &id),
+				checkTags(map[string]string{"app": "web", "env": "test", "owner": "platform"}),
+			),
+		},
+	}
+	// Tag changes apply in place, and removing tags leaves the defaults.
+	for _, tags := range []map[string]string{{"app": "api"}, nil} {
+		attrs := map[string]any{"tags": nil}
+		if tags != nil {
+			attrs["tags"] = tags
+		}
+		steps = append(steps, resource.TestStep{
+			Config: e.config(defaults, testAccServerConfig(attrs)),
+			ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+				plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+			}},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+				checkTags(mergeTags(map[string]string{"env": "acc", "owner": "platform"}, tags)),
+			),
+		})
+	}
+	// Tags edited in the portal are drift.
+	if e.fake != nil {
+		setTags := func(tags map[string]string) func() {
+			return func() { e.fake.Mutate(id, func(s *fakeapi.ServerRecord) { s.Tags = tags }) }
+		}
+		steps = append(steps,
+			resource.TestStep{
+				PreConfig:   setTags(map[string]string{"env": "acc"}),
+				Config:      e.config(defaults, testAccServerConfig(nil)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Out-of-band change detected`),
+			},
+			resource.TestStep{
+				PreConfig: setTags(map[string]string{"env": "acc", "owner": "platform"}),
+				Config:    e.config(defaults, testAccServerConfig(nil)),
+				PlanOnly:  true,
+			},
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+// Without tags or default_tags a server still records an empty tags_all,
+// so tags added in the portal are drift.
+func TestAccServerResource_tagsDriftFromNone(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	config := e.config(nil, testAccServerConfig(nil))
+	var id string
+	setTags := func(tags map[string]string) func() {
+		return func() { e.fake.Mutate(id, func(s *fakeapi.ServerRecord) { s.Tags = tags }) }
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "tags_all.%", "0"),
+				),
+			},
+			{
+				PreConfig:   setTags(map[string]string{"team": "web"}),
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Out-of-band change detected`),
+			},
+			{
+				PreConfig: setTags(nil),
+				Config:    config,
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
+func TestAccServerResource_import(t *testing.T) {
+	e := newAccEnv(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, testAccServerConfig(nil)),
+				Check:  testAccAttr(testAccServerAddr, "id", &id),
+			},
+			{
+				ResourceName:            testAccServerAddr,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				ResourceName:            testAccServerAddr,
+				ImportState:             true,
+				ImportStateId:           "hostname:acc-web-01",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				ResourceName:  testAccServerAddr,
+				ImportState:   true,
+				ImportStateId: "hostname:does-not-exist",
+				ExpectError:   regexp.MustCompile(`Import failed`),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_drift(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	provider := map[string]any{"recreate_on_missing": true}
+	adopt := e.config(provider, testAccServerConfig(map[string]any{"drift_policy": "adopt"}))
+	strict := e.config(provider, testAccServerConfig(nil))
+	var id string
+	rename := func(hostname string) func() {
+		return func() { e.fake.Mutate(id, func(s *fakeapi.ServerRecord) { s.Hostname = hostname }) }
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: adopt,
+				Check:  testAccAttr(testAccServerAddr, "id", &id),
+			},
+			// "adopt" refreshes the remote hostname into state, so the plan
+			// renames it back.
+			{
+				PreConfig:          rename("renamed-in-portal"),
+				Config:             adopt,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPostRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+			},
+			{
+				Config: adopt,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "hostname", "acc-web-01"),
+				),
+			},
+			// The default "error" policy fails the refresh.
+			{
+				Config: strict,
+			},
+			{
+				PreConfig:   rename("renamed-in-portal"),
+				Config:      strict,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Out-of-band change detected`),
+			},
+			// A server deleted out of band leaves state when
+			// recreate_on_missing is set, so it is ordered again.
+			{
+				PreConfig:          func() { e.fake.Remove(id) },
+				Config:             strict,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{PostApplyPostRefresh: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionCreate),
+				}},
+			},
+			{
+				Config: strict,
+				Check:  testAccAttrNot(testAccServerAddr, "id", &id),
+			},
+		},
+	})
+}
+
+// TestAccServerResource_driftRecovery shows the ways out of a refresh that
+// fails under the default "error" policy.
+func TestAccServerResource_driftRecovery(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(nil, testAccServerConfig(nil)),
+				Check:  testAccAttr(testAccServerAddr, "id", &id),
+			},
+			{
+				PreConfig: func() {
+					e.fake.Mutate(id, func(s *fakeapi.ServerRecord) { s.Hostname = "renamed-in-portal" })
+				},
+				Config:      e.config(nil, testAccServerConfig(nil)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Out-of-band change detected`),
+			},
+			// Adding drift_policy to the resource does not help the next
+			// refresh, which still runs against the prior state.
+			{
+				Config:             e.config(nil, testAccServerConfig(map[string]any{"drift_policy": "adopt"})),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`Out-of-band change detected`),
+			},
+			// The provider-level policy does, and the apply renames the
+			// server back.
+			{
+				Config: e.config(map[string]any{"drift_policy": "adopt"}, testAccServerConfig(nil)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(testAccServerAddr, plancheck.ResourceActionUpdate),
+				}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testAccServerAddr, "id", &id),
+					resource.TestCheckResourceAttr(testAccServerAddr, "hostname", "acc-web-01"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckOnlyServer fails unless the server at addr is the only one
+// the fake API holds.
+func (e *accEnv) testAccCheckOnlyServer(addr string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[addr]
+		if !ok {
+			return fmt.Errorf("%s not in state", addr)
+		}
+		if servers := e.fake.Servers(); len(servers) != 1 || servers[0].ID != rs.Primary.ID {
+			return fmt.Errorf("expected %s to be the only server, got %v", rs.Primary.ID, servers)
+		}
+		return nil
+	}
+}
+
+func TestAccServerResource_lostOrder(t *testing.T) {
+	provider := map[string]any{"retry_max_wait": "10ms"}
+
+	// A dropped allocate response is retried with the same idempotency key.
+	t.Run("dropped response", func(t *testing.T) {
+		e := newAccEnv(t)
+		e.requireFake()
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			CheckDestroy:             e.checkDestroy,
+			Steps: []resource.TestStep{{
+				PreConfig: func() { e.fake.DropAllocateResponses(1) },
+				Config:    e.config(provider, testAccServerConfig(nil)),
+				Check:     e.testAccCheckOnlyServer(testAccServerAddr),
+			}},
+		})
+	})
+
+	// When every response is lost, the server the order made is found and
+	// adopted. Replacing it with an unchanged configuration, as a taint
+	// does, is a new order even while the old server is still listed. While
+	// every response is lost the replayed order cannot be told apart from a
+	// new one, so nothing is adopted or ordered.
+	t.Run("lost responses", func(t *testing.T) {
+		e := newAccEnv(t)
+		e.requireFake()
+		config := e.config(provider, testAccServerConfig(map[string]any{
+			"hostname":  "acc-lost-01",
+			"lifecycle": testAccCreateBeforeDestroy,
+		}))
+		var old string
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			CheckDestroy:             e.checkDestroy,
+			Steps: []resource.TestStep{
+				{
+					PreConfig: func() { e.fake.DropAllocateResponses(100) },
+					Config:    config,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						testAccAttr(testAccServerAddr, "id", &old),
+						e.testAccCheckOnlyServer(testAccServerAddr),
+					),
+				},
+				{
+					PreConfig: func() {
+						e.fake.Mutate(old, func(s *fakeapi.ServerRecord) { s.CreatedAt = s.CreatedAt.Add(-time.Hour) })
+					},
+					Config:      config,
+					Taint:       []string{testAccServerAddr},
+					ExpectError: regexp.MustCompile(`Create failed`),
+				},
+				{
+					PreConfig: func() { e.fake.DropAllocateResponses(0) },
+					Config:    config,
+					Taint:     []string{testAccServerAddr},
+					Check: resource.ComposeAggregateTestCheckFunc(
+						testAccAttrNot(testAccServerAddr, "id", &old),
+						e.testAccCheckOnlyServer(testAccServerAddr),
+					),
+				},
+			},
+		})
+	})
+
+	// User data is never returned by the API, so an order carrying it is
+	// not adopted from the list: the apply fails, and the next one gets
+	// the original server back through the idempotency key.
+	t.Run("user data", func(t *testing.T) {
+		e := newAccEnv(t)
+		e.requireFake()
+		config := e.config(provider, testAccServerConfig(map[string]any{"hostname": "acc-lost-02", "user_data": "#!/bin/sh\n"}))
+		var lost []fakeapi.ServerRecord
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			CheckDestroy:             e.checkDestroy,
+			Steps: []resource.TestStep{
+				{
+					PreConfig:   func() { e.fake.DropAllocateResponses(100) },
+					Config:      config,
+					ExpectError: regexp.MustCompile(`Create failed`),
+				},
+				{
+					PreConfig: func() {
+						e.fake.DropAllocateResponses(0)
+						lost = e.fake.Servers()
+					},
+					Config: config,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						e.testAccCheckOnlyServer(testAccServerAddr),
+						resource.TestCheckResourceAttrWith(testAccServerAddr, "id", func(id string) error {
+							if len(lost) != 1 || id != lost[0].ID {
+								return fmt.Errorf("expected the lost order %v to be returned, got %s", lost, id)
+							}
+							return nil
+						}),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccServerResource_replaceUnchanged(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	config := e.config(nil, testAccServerConfig(map[string]any{
+		"hostname":  "acc-cbd-01",
+		"lifecycle": testAccCreateBeforeDestroy,
+	}))
+	var first, firstKey, second string
+	// The replacement replays the old server's order, and the old server
+	// is older than an apply retried after a lost response would adopt.
+	age := func(id *string) func() {
+		return func() {
+			e.fake.Mutate(*id, func(s *fakeapi.ServerRecord) { s.CreatedAt = s.CreatedAt.Add(-time.Hour) })
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttr(testAccServerAddr, "id", &first),
+					testAccAttr(testAccServerAddr, "idempotency_key", &firstKey),
+				),
+			},
+			// Under create_before_destroy the old server is still there
+			// when the replacement is ordered, with the same configuration.
+			{
+				PreConfig: age(&first),
+				Config:    config,
+				Taint:     []string{testAccServerAddr},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &first),
+					testAccAttrNot(testAccServerAddr, "idempotency_key", &firstKey),
+					testAccAttr(testAccServerAddr, "id", &second),
+					e.testAccCheckOnlyServer(testAccServerAddr),
+				),
+			},
+			// So is a replacement of the replacement.
+			{
+				PreConfig: age(&second),
+				Config:    config,
+				Taint:     []string{testAccServerAddr},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &first, &second),
+					e.testAccCheckOnlyServer(testAccServerAddr),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_reapplyAfterDestroy(t *testing.T) {
+	e := newAccEnv(t)
+	e.requireFake()
+	config := e.config(map[string]any{"recreate_on_missing": true}, testAccServerConfig(map[string]any{"hostname": "acc-again-01"}))
+	var first, second string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             e.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccAttr(testAccServerAddr, "id", &first),
+			},
+			{
+				Config:  config,
+				Destroy: true,
+			},
+			// The same configuration replays the destroyed server's order,
+			// which is ordered again rather than waited on.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &first),
+					testAccAttr(testAccServerAddr, "id", &second),
+					e.testAccCheckOnlyServer(testAccServerAddr),
+				),
+			},
+			// So does one deleted out of band and dropped from state.
+			{
+				PreConfig: func() { e.fake.Remove(second) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccAttrNot(testAccServerAddr, "id", &first, &second),
+					e.testAccCheckOnlyServer(testAccServerAddr),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_planValidation(t *testing.T) {
+	e := newAccEnv(t)
+	provider := map[string]any{"max_monthly_spend_per_server": 200}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		summary string
+	}{
+		{"plan not sold in location", map[string]any{"plan_id": 11, "location_id": 2}, "Plan not available in location"},
+		{"unsupported raid", map[string]any{"raid": 10}, "Invalid RAID for plan"},
+		{"unknown os", map[string]any{"os_id": 1}, "Unknown operating system"},
+		{"over budget", map[string]any{"plan_id": 12}, "Server over budget"},
+		{"invalid hostname", map[string]any{"hostname": "-web"}, "Invalid hostname"},
+	}
+	var steps []resource.TestStep
+	for _, tt := range tests {
+		steps = append(steps, resource.TestStep{
+			Config:      e.config(provider, testAccServerConfig(tt.config)),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(tt.summary),
+		})
+	}
+	// Values unknown at plan time are checked on apply instead.
+	steps = append(steps, resource.TestStep{
+		Config: e.config(provider,
+			testAccResource("terraform_data", "plan", map[string]any{"input": 10}),
+			testAccServerConfig(map[string]any{"plan_id": hclExpr("terraform_data.plan.output")})),
+		PlanOnly:           true,
+		ExpectNonEmptyPlan: true,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+	if e.fake != nil && len(e.fake.Servers()) != 0 {
+		t.Fatalf("expected no servers to be ordered, got %v", e.fake.Servers())
+	}
+}
@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/rackdog/terraform-provider-rackdog/fakeapi"
+)
+
+func serverSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	var resp resource.SchemaResponse
+	NewServerResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", resp.Diagnostics)
+	}
+	return resp
+}
+
+// serverObject builds a rackdog_server object value; attributes not in
+// vals are null.
+func serverObject(t *testing.T, sch resource.SchemaResponse, vals map[string]any) tftypes.Value {
+	t.Helper()
+	typ := sch.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, at := range typ.AttributeTypes {
+		v, ok := vals[name]
+		if !ok {
+			attrs[name] = tftypes.NewValue(at, nil)
+			continue
+		}
+		if n, isInt := v.(int); isInt {
+			v = int64(n)
+		}
+		attrs[name] = tftypes.NewValue(at, v)
+	}
+	return tftypes.NewValue(typ, attrs)
+}
+
+func catalogServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/ordering/plans":
+			json.NewEncoder(w).Encode(map[string]any{
+				"success": true,
+				"data": []map[string]any{{
+					"id":        10,
+					"name":      "Test Plan",
+					"locations": []map[string]any{{"id": 1, "keyword": "ny", "monthlyPrice": 99}},
+				}},
+			})
+		case "/v1/ordering/os":
+			json.NewEncoder(w).Encode(map[string]any{
+				"success": true,
+				"data":    []map[string]any{{"id": 62, "name": "Ubuntu 24.04"}},
+			})
+		case "/v1/ordering/plans/10/raid/1/check":
+			json.NewEncoder(w).Encode(map[string]any{"success": true})
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "message": "RAID not supported"})
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestServerResource_ModifyPlan(t *testing.T) {
+	sch := serverSchema(t)
+	srv := catalogServer(t)
+	c := NewClient(srv.URL, "k123")
+	r := &serverResource{client: c, catalog: newCatalog(c, defaultCatalogTTL)}
+
+	tests := []struct {
+		name      string
+		plan      map[string]any
+		wantPaths []path.Path
+	}{
+		{
+			name: "valid combination",
+			plan: map[string]any{"plan_id": 10, "location_id": 1, "os_id": 62, "raid": 1},
+		},
+		{
+			name:      "unknown plan",
+			plan:      map[string]any{"plan_id": 11, "location_id": 1, "os_id": 62},
+			wantPaths: []path.Path{path.Root("plan_id")},
+		},
+		{
+			name:      "plan not sold in location",
+			plan:      map[string]any{"plan_id": 10, "location_id": 2, "os_id": 62},
+			wantPaths: []path.Path{path.Root("location_id")},
+		},
+		{
+			name:      "unknown os and raid",
+			plan:      map[string]any{"plan_id": 10, "location_id": 1, "os_id": 99, "raid": 5},
+			wantPaths: []path.Path{path.Root("os_id"), path.Root("raid")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: sch.Schema, Raw: serverObject(t, sch, tt.plan)},
+				Plan:   tfsdk.Plan{Schema: sch.Schema, Raw: serverObject(t, sch, tt.plan)},
+				State:  tfsdk.State{Schema: sch.Schema, Raw: tftypes.NewValue(sch.Schema.Type().TerraformType(context.Background()), nil)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if resp.Diagnostics.ErrorsCount() != len(tt.wantPaths) {
+				t.Fatalf("expected %d errors, got %v", len(tt.wantPaths), resp.Diagnostics)
+			}
+			for i, want := range tt.wantPaths {
+				got, ok := resp.Diagnostics.Errors()[i].(diag.DiagnosticWithPath)
+				if !ok || !got.Path().Equal(want) {
+					t.Errorf("expected error %d on %s, got %v", i, want, resp.Diagnostics.Errors()[i])
+				}
+			}
+		})
+	}
+}
+
+func TestServerResource_ModifyPlanPrice(t *testing.T) {
+	sch := serverSchema(t)
+	srv := catalogServer(t)
+	c := NewClient(srv.URL, "k123")
+
+	for _, tt := range []struct {
+		budget  float64
+		wantErr bool
+	}{{0, false}, {150, false}, {50, true}} {
+		r := &serverResource{
+			client:  c,
+			catalog: newCatalog(c, defaultCatalogTTL),
+			cfg:     resolvedConfig{MaxMonthlySpendPerServer: tt.budget},
+		}
+		config := serverObject(t, sch, map[string]any{"plan_id": 10, "location_id": 1, "os_id": 62})
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: sch.Schema, Raw: config},
+			Plan:   tfsdk.Plan{Schema: sch.Schema, Raw: config},
+			State:  tfsdk.State{Schema: sch.Schema, Raw: tftypes.NewValue(sch.Schema.Type().TerraformType(context.Background()), nil)},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != tt.wantErr {
+			t.Fatalf("budget %v: expected error=%v, got %v", tt.budget, tt.wantErr, resp.Diagnostics)
+		}
+		var price types.Float64
+		resp.Plan.GetAttribute(context.Background(), path.Root("monthly_price"), &price)
+		if price.ValueFloat64() != 99 {
+			t.Errorf("budget %v: expected planned monthly_price 99, got %v", tt.budget, price)
+		}
+	}
+}
+
+func TestParseMonthlyPrice(t *testing.T) {
+	tests := map[string]float64{"99": 99, "$1,299.99": 1299.99, "149.50 USD": 149.5}
+	for in, want := range tests {
+		got, ok := parseMonthlyPrice(&in)
+		if !ok || got != want {
+			t.Errorf("parseMonthlyPrice(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	bad := "call us"
+	if _, ok := parseMonthlyPrice(&bad); ok {
+		t.Error("expected unparseable price to be rejected")
+	}
+	if _, ok := parseMonthlyPrice(nil); ok {
+		t.Error("expected nil price to be rejected")
+	}
+}
+
+func TestOrderKey(t *testing.T) {
+	hostname := "web-01"
+	in := &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, Hostname: &hostname, SSHKeyIDs: []string{"k1", "k2"}}
+	key := orderKey(in)
+	if again := orderKey(in); again != key {
+		t.Errorf("expected a stable key, got %q then %q", key, again)
+	}
+	reordered := *in
+	reordered.SSHKeyIDs = []string{"k2", "k1"}
+	if got := orderKey(&reordered); got != key {
+		t.Errorf("expected the SSH key order not to matter, got %q and %q", key, got)
+	}
+	changed := *in
+	changed.UserData = "IyEvYmluL3NoCg=="
+	if orderKey(&changed) == key {
+		t.Error("expected user data to change the key")
+	}
+	anonymous := *in
+	anonymous.Hostname = nil
+	if orderKey(&anonymous) == orderKey(&anonymous) {
+		t.Error("expected servers without a hostname to get random keys")
+	}
+}
+
+func TestMadeByOrder(t *testing.T) {
+	orderedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	raid, otherRaid := 1, 0
+	in := &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62, Raid: &raid, SSHKeyIDs: []string{"k1", "k2"}, Tags: map[string]string{"team": "web"}}
+	match := func() *Server {
+		on := "PROVISIONING"
+		return &Server{
+			Plan: ServerPlan{ID: 10}, Location: ServerLocation{ID: 1}, ServerOS: &ServerOS{ID: 62}, Raid: &raid,
+			SSHKeyIDs: []string{"k2", "k1"}, Tags: map[string]string{"team": "web"}, PowerStatus: &on,
+			CreatedAt: orderedAt.Add(-10 * time.Second).Format(time.RFC3339),
+		}
+	}
+
+	tests := map[string]func(s *Server){
+		"created before the order": func(s *Server) { s.CreatedAt = orderedAt.Add(-time.Hour).Format(time.RFC3339) },
+		"being deleted":            func(s *Server) { deleting := "DELETING"; s.PowerStatus = &deleting },
+		"other plan":               func(s *Server) { s.Plan.ID = 11 },
+		"other location":           func(s *Server) { s.Location.ID = 2 },
+		"other OS":                 func(s *Server) { s.ServerOS.ID = 70 },
+		"unknown OS":               func(s *Server) { s.ServerOS = nil },
+		"other raid":               func(s *Server) { s.Raid = &otherRaid },
+		"other SSH keys":           func(s *Server) { s.SSHKeyIDs = []string{"k1"} },
+		"other tags":               func(s *Server) { s.Tags = nil },
+	}
+	if !madeByOrder(match(), in, orderedAt) {
+		t.Fatal("expected the matching server to be accepted")
+	}
+	for name, mutate := range tests {
+		s := match()
+		mutate(s)
+		if madeByOrder(s, in, orderedAt) {
+			t.Errorf("%s: expected the server to be rejected", name)
+		}
+	}
+}
+
+func TestServerResource_StaleOrder(t *testing.T) {
+	oldPoll, oldVisible := serverPollInterval, orderVisibleTimeout
+	serverPollInterval, orderVisibleTimeout = time.Millisecond, 20*time.Millisecond
+	defer func() { serverPollInterval, orderVisibleTimeout = oldPoll, oldVisible }()
+
+	api := fakeapi.New(fakeapi.Options{APIKey: "k123"})
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	r := &serverResource{client: NewClient(srv.URL, "k123")}
+	ctx := context.Background()
+
+	orderedAt := time.Now()
+	earlier := func(age time.Duration, status string) func(*fakeapi.ServerRecord) {
+		return func(s *fakeapi.ServerRecord) {
+			s.CreatedAt = orderedAt.Add(-age)
+			if status != "" {
+				s.PowerStatus = status
+			}
+		}
+	}
+	tests := []struct {
+		name   string
+		mutate func(*fakeapi.ServerRecord)
+		remove bool
+		want   bool
+	}{
+		{name: "this order"},
+		{name: "failed in this order", mutate: earlier(0, fakeapi.StatusFailed)},
+		{name: "recent earlier order", mutate: earlier(5*time.Minute, "")},
+		{name: "earlier order managed elsewhere", mutate: earlier(time.Hour, ""), want: true},
+		{name: "earlier order failed", mutate: earlier(5*time.Minute, fakeapi.StatusFailed), want: true},
+		{name: "earlier order deleting", mutate: earlier(5*time.Minute, "DELETING"), want: true},
+		{name: "earlier order gone", remove: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := r.client.CreateServer(ctx, &CreateServerRequest{PlanID: 10, LocationID: 1, OSID: 62})
+			if err != nil {
+				t.Fatalf("CreateServer: %v", err)
+			}
+			if tt.mutate != nil {
+				api.Mutate(created.ID, tt.mutate)
+			}
+			if tt.remove {
+				api.Remove(created.ID)
+			}
+			if got := r.staleOrder(ctx, created.ID, orderedAt); got != tt.want {
+				t.Errorf("staleOrder = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerResource_ValidateConfig(t *testing.T) {
+	sch := serverSchema(t)
+	r := &serverResource{}
+
+	tests := []struct {
+		hostname string
+		wantErr  bool
+	}{
+		{"web-01", false},
+		{"web-01.example.com", false},
+		{"-web", true},
+		{"web_01", true},
+	}
+	for _, tt := range tests {
+		req := resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: sch.Schema, Raw: serverObject(t, sch, map[string]any{
+				"plan_id": 10, "location_id": 1, "os_id": 62, "hostname": tt.hostname,
+			})},
+		}
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() != tt.wantErr {
+			t.Errorf("hostname %q: expected error=%v, got %v", tt.hostname, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+// Terraform before 1.11 cannot send write-only arguments, so user data
+// needs the capability.
+func TestServerResource_UserDataNeedsWriteOnly(t *testing.T) {
+	sch := serverSchema(t)
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := tfprotov6.NewDynamicValue(sch.Schema.Type().TerraformType(context.Background()),
+		serverObject(t, sch, map[string]any{"plan_id": 10, "location_id": 1, "os_id": 62, "user_data": "#!/bin/sh\n"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, allowed := range []bool{false, true} {
+		resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+			TypeName:           "rackdog_server",
+			Config:             &config,
+			ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{WriteOnlyAttributesAllowed: allowed},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rejected := slices.ContainsFunc(resp.Diagnostics, func(d *tfprotov6.Diagnostic) bool {
+			return d.Summary == "WriteOnly Attribute Not Allowed"
+		})
+		if rejected == allowed {
+			t.Errorf("write-only allowed %v: got diagnostics %v", allowed, resp.Diagnostics)
+		}
+	}
+}
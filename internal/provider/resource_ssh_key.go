@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sshKeyResource struct{ client *Client }
+
+var (
+	_ resource.ResourceWithImportState    = &sshKeyResource{}
+	_ resource.ResourceWithValidateConfig = &sshKeyResource{}
+)
+
+// sshKeyTypes are the key algorithms accepted in public_key.
+var sshKeyTypes = []string{
+	"ssh-rsa",
+	"ssh-ed25519",
+	"ecdsa-sha2-nistp256",
+	"ecdsa-sha2-nistp384",
+	"ecdsa-sha2-nistp521",
+	"sk-ssh-ed25519@openssh.com",
+	"sk-ecdsa-sha2-nistp256@openssh.com",
+}
+
+// sshKeyFieldPaths maps SSH key request fields to schema attributes.
+var sshKeyFieldPaths = map[string]path.Path{
+	"name":      path.Root("name"),
+	"publicKey": path.Root("public_key"),
+}
+
+func NewSSHKeyResource() resource.Resource { return &sshKeyResource{} }
+
+type sshKeyModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+func (r *sshKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (r *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SSH public key stored in the Rackdog account. Reference it from rackdog_server.ssh_key_ids " +
+			"to install it on new servers.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Public key in authorized_keys format, e.g. the contents of ~/.ssh/id_ed25519.pub.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA256 fingerprint of the key, as shown by ssh-keygen -l.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *sshKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	pd := req.ProviderData.(*ProviderData)
+	r.client = pd.Client
+}
+
+func (r *sshKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config sshKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if k := config.PublicKey; !k.IsNull() && !k.IsUnknown() {
+		if err := checkAuthorizedKey(k.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("public_key"), "Invalid public key", err.Error())
+		}
+	}
+}
+
+// checkAuthorizedKey checks that s looks like a single authorized_keys
+// entry: a known key type, base64 key data and an optional comment.
+func checkAuthorizedKey(s string) error {
+	if strings.Contains(strings.TrimSpace(s), "\n") {
+		return fmt.Errorf("expected a single public key, got several lines")
+	}
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return fmt.Errorf("expected \"<type> <base64 key> [comment]\", e.g. the contents of ~/.ssh/id_ed25519.pub")
+	}
+	if !slices.Contains(sshKeyTypes, fields[0]) {
+		return fmt.Errorf("unsupported key type %q; expected one of %s", fields[0], quoteJoin(sshKeyTypes))
+	}
+	if _, err := base64.StdEncoding.DecodeString(fields[1]); err != nil {
+		return fmt.Errorf("key data is not valid base64")
+	}
+	return nil
+}
+
+func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var plan sshKeyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	k, err := r.client.CreateSSHKey(ctx, &CreateSSHKeyRequest{
+		Name:      plan.Name.ValueString(),
+		PublicKey: strings.TrimSpace(plan.PublicKey.ValueString()),
+	})
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Create failed", err, sshKeyFieldPaths)
+		return
+	}
+
+	plan.ID = types.StringValue(k.ID)
+	plan.Fingerprint = types.StringValue(k.Fingerprint)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var state sshKeyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	k, err := r.client.GetSSHKey(ctx, state.ID.ValueString())
+	if err != nil {
+		if IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(k.Name)
+	// Keep the configured spelling (e.g. a trailing newline from file())
+	// unless the stored key is actually different.
+	if strings.TrimSpace(state.PublicKey.ValueString()) != strings.TrimSpace(k.PublicKey) {
+		state.PublicKey = types.StringValue(k.PublicKey)
+	}
+	state.Fingerprint = types.StringValue(k.Fingerprint)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called: every configurable attribute requires
+// replacement.
+func (r *sshKeyResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Update not supported", "rackdog_ssh_key attributes can only change by replacement.")
+}
+
+func (r *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client was nil")
+		return
+	}
+
+	var state sshKeyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteSSHKey(ctx, state.ID.ValueString()); err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+func (r *sshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
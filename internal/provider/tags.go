@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Tag limits enforced by the API.
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// mergeTags returns defaults overridden by tags, or nil when both are
+// empty. This is the value of tags_all.
+func mergeTags(defaults, tags map[string]string) map[string]string {
+	if len(defaults) == 0 && len(tags) == 0 {
+		return nil
+	}
+	out := maps.Clone(defaults)
+	if out == nil {
+		out = map[string]string{}
+	}
+	maps.Copy(out, tags)
+	return out
+}
+
+// withoutDefaultTags returns the tags in all that are not inherited from
+// defaults unchanged, i.e. what the resource's own tags must hold for
+// tags_all to equal all.
+func withoutDefaultTags(all, defaults map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range all {
+		if d, ok := defaults[k]; !ok || d != v {
+			out[k] = v
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// knownTags reads a map of strings, reporting false when the map or any
+// value is unknown.
+func knownTags(v types.Map) (map[string]string, bool) {
+	if v.IsUnknown() {
+		return nil, false
+	}
+	if v.IsNull() {
+		return nil, true
+	}
+	out := make(map[string]string, len(v.Elements()))
+	for k, e := range v.Elements() {
+		s, ok := e.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, false
+		}
+		out[k] = s.ValueString()
+	}
+	return out, true
+}
+
+// tagsValue converts tags to a map value, null when empty.
+func tagsValue(tags map[string]string) types.Map {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	elems := make(map[string]attr.Value, len(tags))
+	for k, v := range tags {
+		elems[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+// tagsAllValue converts merged tags to a tags_all value. Unlike tags it is
+// an empty map rather than null when there are none, so an untagged server
+// still has its tags recorded and tags added outside Terraform are drift.
+func tagsAllValue(tags map[string]string) types.Map {
+	if len(tags) == 0 {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	return tagsValue(tags)
+}
+
+// checkTags reports the first tag the API would reject.
+func checkTags(tags map[string]string) error {
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		switch {
+		case strings.TrimSpace(k) == "":
+			return fmt.Errorf("tag keys must not be empty")
+		case len(k) > maxTagKeyLength:
+			return fmt.Errorf("tag key %q is longer than %d characters", k, maxTagKeyLength)
+		case len(tags[k]) > maxTagValueLength:
+			return fmt.Errorf("value of tag %q is longer than %d characters", k, maxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// formatTags renders tags for drift messages, with sorted keys.
+func formatTags(tags map[string]string) string {
+	parts := make([]string, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		parts = append(parts, fmt.Sprintf("%q=%q", k, tags[k]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
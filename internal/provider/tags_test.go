@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeTags(t *testing.T) {
+	defaults := map[string]string{"env": "prod", "owner": "platform"}
+	tags := map[string]string{"env": "staging", "app": "web"}
+
+	got := mergeTags(defaults, tags)
+	want := map[string]string{"env": "staging", "owner": "platform", "app": "web"}
+	if !maps.Equal(got, want) {
+		t.Fatalf("mergeTags() = %v, want %v", got, want)
+	}
+	if defaults["env"] != "prod" {
+		t.Fatal("mergeTags modified the defaults")
+	}
+	if got := mergeTags(nil, map[string]string{}); got != nil {
+		t.Fatalf("expected nil without tags, got %v", got)
+	}
+
+	// withoutDefaultTags inverts the merge.
+	if own := withoutDefaultTags(got, defaults); !maps.Equal(own, tags) {
+		t.Fatalf("withoutDefaultTags() = %v, want %v", own, tags)
+	}
+	if own := withoutDefaultTags(defaults, defaults); own != nil {
+		t.Fatalf("expected nil when every tag is a default, got %v", own)
+	}
+}
+
+func TestKnownTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     types.Map
+		want      map[string]string
+		wantKnown bool
+	}{
+		{"null", types.MapNull(types.StringType), nil, true},
+		{"unknown", types.MapUnknown(types.StringType), nil, false},
+		{"values", tagsValue(map[string]string{"env": "prod"}), map[string]string{"env": "prod"}, true},
+		{"unknown value", types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringUnknown()}), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := knownTags(tt.value)
+			if known != tt.wantKnown || !maps.Equal(got, tt.want) {
+				t.Fatalf("knownTags() = %v, %v; want %v, %v", got, known, tt.want, tt.wantKnown)
+			}
+		})
+	}
+
+	if !tagsValue(map[string]string{}).IsNull() {
+		t.Fatal("expected empty tags to be null")
+	}
+	if all := tagsAllValue(nil); all.IsNull() || len(all.Elements()) != 0 {
+		t.Fatalf("expected empty tags_all to be an empty map, got %v", all)
+	}
+}
+
+func TestCheckTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr string
+	}{
+		{"valid", map[string]string{"env": "prod", "empty": ""}, ""},
+		{"empty key", map[string]string{" ": "x"}, "must not be empty"},
+		{"long key", map[string]string{strings.Repeat("k", maxTagKeyLength+1): "x"}, "tag key"},
+		{"long value", map[string]string{"env": strings.Repeat("v", maxTagValueLength+1)}, `value of tag "env"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTags(tt.tags)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if got := formatTags(map[string]string{"b": "2", "a": "1"}); got != `{"a"="1", "b"="2"}` {
+		t.Fatalf("formatTags() = %s", got)
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsBlock returns a `timeouts { ... }` block with one optional
+// duration string per operation name.
+func timeoutsBlock(ops ...string) schema.SingleNestedBlock {
+	attrs := make(map[string]schema.Attribute, len(ops))
+	for _, op := range ops {
+		attrs[op] = schema.StringAttribute{
+			Optional:    true,
+			Description: "How long to wait for the " + op + " operation, e.g. \"30m\".",
+			Validators:  []validator.String{durationValidator{}},
+		}
+	}
+	return schema.SingleNestedBlock{
+		Description: "Operation timeouts.",
+		Attributes:  attrs,
+	}
+}
+
+// timeoutFor reads op from a timeouts object, falling back to def when the
+// block or the value is unset.
+func timeoutFor(_ context.Context, obj types.Object, op string, def time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return def, diags
+	}
+	v, ok := obj.Attributes()[op].(types.String)
+	if !ok || v.IsNull() || v.IsUnknown() {
+		return def, diags
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil {
+		diags.AddError("Invalid timeout", "timeouts."+op+": "+err.Error())
+		return def, diags
+	}
+	return d, diags
+}
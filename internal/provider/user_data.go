@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxUserDataSize is the largest decoded user data the API accepts.
+const maxUserDataSize = 64 << 10
+
+// userData returns the decoded user data configured in m: user_data as
+// is, or user_data_base64 decoded (it may hold gzip-compressed data, which
+// cloud-init unpacks itself). data is nil when neither is set, and known is
+// false while either is unknown.
+func (m *serverModel) userData() (data []byte, known bool, err error) {
+	if m.UserData.IsUnknown() || m.UserDataBase64.IsUnknown() {
+		return nil, false, nil
+	}
+	switch {
+	case !m.UserData.IsNull() && !m.UserDataBase64.IsNull():
+		return nil, true, fmt.Errorf("only one of user_data and user_data_base64 can be set")
+	case !m.UserData.IsNull():
+		data = []byte(m.UserData.ValueString())
+	case !m.UserDataBase64.IsNull():
+		data, err = base64.StdEncoding.DecodeString(strings.TrimSpace(m.UserDataBase64.ValueString()))
+		if err != nil {
+			return nil, true, fmt.Errorf("user_data_base64 is not valid base64: %w", err)
+		}
+	default:
+		return nil, true, nil
+	}
+	if len(data) > maxUserDataSize {
+		return nil, true, fmt.Errorf("user data is %d bytes, more than the %d bytes the API accepts; compress it with base64gzip() and pass it as user_data_base64", len(data), maxUserDataSize)
+	}
+	return data, true, nil
+}
+
+// configUserData reads the write-only user data from config into m, whose
+// plan and state never hold it.
+func (m *serverModel) configUserData(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	diags := config.GetAttribute(ctx, path.Root("user_data"), &m.UserData)
+	diags.Append(config.GetAttribute(ctx, path.Root("user_data_base64"), &m.UserDataBase64)...)
+	return diags
+}
+
+// userDataHashValue is the user_data_hash for data, null when data is nil.
+func userDataHashValue(data []byte) types.String {
+	if data == nil {
+		return types.StringNull()
+	}
+	sum := sha256.Sum256(data)
+	return types.StringValue("sha256:" + hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestServerModelUserData(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("#cloud-config\n"))
+	zw.Close()
+
+	tests := []struct {
+		name      string
+		plain     types.String
+		encoded   types.String
+		want      []byte
+		wantKnown bool
+		wantErr   string
+	}{
+		{"unset", types.StringNull(), types.StringNull(), nil, true, ""},
+		{"plain", types.StringValue("#cloud-config\n"), types.StringNull(), []byte("#cloud-config\n"), true, ""},
+		{"base64 gzip is passed through", types.StringNull(), types.StringValue(base64.StdEncoding.EncodeToString(gz.Bytes())), gz.Bytes(), true, ""},
+		{"unknown", types.StringUnknown(), types.StringNull(), nil, false, ""},
+		{"both set", types.StringValue("a"), types.StringValue("YQ=="), nil, true, "only one of"},
+		{"bad base64", types.StringNull(), types.StringValue("not base64!"), nil, true, "not valid base64"},
+		{"too large", types.StringValue(strings.Repeat("x", maxUserDataSize+1)), types.StringNull(), nil, true, "more than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := serverModel{UserData: tt.plain, UserDataBase64: tt.encoded}
+			got, known, err := m.userData()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || known != tt.wantKnown || !bytes.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Fatalf("userData() = %q, %v, %v; want %q, %v", got, known, err, tt.want, tt.wantKnown)
+			}
+		})
+	}
+
+	if h := userDataHashValue([]byte("hello")); h.ValueString() != "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Fatalf("unexpected hash %s", h)
+	}
+	if !userDataHashValue(nil).IsNull() {
+		t.Fatal("expected a null hash without user data")
+	}
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// serverPollInterval is how often GetServer is polled while waiting on a
+// long-running server operation. Tests shorten it.
+var serverPollInterval = 15 * time.Second
+
+// actionStartTimeout bounds how long waitForServerAction waits for an
+// accepted action to show up in the server's status. Tests shorten it.
+var actionStartTimeout = 5 * time.Minute
+
+// orderVisibleTimeout bounds how long the server an allocate returned may
+// be missing (404) before it is taken to be gone rather than not visible
+// yet. Tests shorten it.
+var orderVisibleTimeout = 2 * time.Minute
+
+const (
+	defaultCreateTimeout = 60 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+)
+
+// ServerFailedError is returned when the API reports a terminal failure
+// status while we are waiting on a server.
+type ServerFailedError struct {
+	ID     string
+	Status string
+}
+
+func (e *ServerFailedError) Error() string {
+	return fmt.Sprintf("server %s reported status %q", e.ID, e.Status)
+}
+
+// WaitTimeoutError is returned when a server does not reach the wanted
+// status before the timeout elapses.
+type WaitTimeoutError struct {
+	ID         string
+	LastStatus string
+	Timeout    time.Duration
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("server %s did not reach the expected status within %s (last status %q)", e.ID, e.Timeout, e.LastStatus)
+}
+
+func powerStatus(s *Server) string {
+	if s == nil || s.PowerStatus == nil {
+		return ""
+	}
+	return *s.PowerStatus
+}
+
+func isReadyPowerStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "on", "running", "active":
+		return true
+	}
+	return false
+}
+
+func isOffPowerStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "off", "stopped", "powered_off":
+		return true
+	}
+	return false
+}
+
+// powerStateOf maps a devicePowerStatus onto the power_state values "on"
+// and "off". Transitional statuses map to "".
+func powerStateOf(status string) string {
+	switch {
+	case isReadyPowerStatus(status):
+		return PowerActionOn
+	case isOffPowerStatus(status):
+		return PowerActionOff
+	}
+	return ""
+}
+
+func isFailedPowerStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "failed", "error", "provisioning_failed":
+		return true
+	}
+	return false
+}
+
+// isDeletingPowerStatus reports whether a server is on its way out.
+func isDeletingPowerStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "deleting", "destroying", "terminating":
+		return true
+	}
+	return false
+}
+
+// ActionNotObservedError is returned, along with the server, when an
+// accepted action never showed in the server's status within
+// actionStartTimeout while the server reports the end status. A short
+// action, such as a reboot, can start and finish between two polls, so
+// callers take it as done and warn.
+type ActionNotObservedError struct {
+	ID      string
+	Status  string
+	Timeout time.Duration
+}
+
+func (e *ActionNotObservedError) Error() string {
+	return fmt.Sprintf("server %s reported status %q throughout the %s after the action was accepted, so it was never seen running; "+
+		"it most likely finished between two status checks", e.ID, e.Status, e.Timeout)
+}
+
+// waitForServerStatus polls GetServer until done reports true for the
+// server's devicePowerStatus, a failure status is reported, or timeout
+// elapses. A 404 is treated as "not visible yet" since freshly allocated
+// servers can take a moment to show up.
+func waitForServerStatus(ctx context.Context, c *Client, id string, timeout time.Duration, done func(string) bool) (*Server, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(serverPollInterval)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		s, err := c.GetServer(ctx, id)
+		if err == nil {
+			last = powerStatus(s)
+			if done(last) {
+				return s, nil
+			}
+			if isFailedPowerStatus(last) {
+				return s, &ServerFailedError{ID: id, Status: last}
+			}
+			tflog.Debug(ctx, "Waiting for server", map[string]any{"id": id, "status": last})
+		} else if !IsNotFound(err) && ctx.Err() == nil {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &WaitTimeoutError{ID: id, LastStatus: last, Timeout: timeout}
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForServerAction waits for an action the API accepted asynchronously,
+// such as a reinstall or reboot, whose end status is one done already
+// accepts. The server keeps reporting its old status until the action
+// starts, so it must first leave that status before the wait for done can
+// mean anything. A server that never leaves the done status is returned
+// with an *ActionNotObservedError.
+func waitForServerAction(ctx context.Context, c *Client, id string, timeout time.Duration, done func(string) bool) (*Server, error) {
+	start := time.Now()
+	startTimeout := min(timeout, actionStartTimeout)
+	if _, err := waitForServerStatus(ctx, c, id, startTimeout, func(s string) bool { return !done(s) }); err != nil {
+		var te *WaitTimeoutError
+		if !errors.As(err, &te) {
+			return nil, err
+		}
+		if !done(te.LastStatus) {
+			return nil, fmt.Errorf("server %s was not visible %s after the action was accepted", id, startTimeout)
+		}
+		s, err := c.GetServer(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return s, &ActionNotObservedError{ID: id, Status: te.LastStatus, Timeout: startTimeout}
+	}
+	return waitForServerStatus(ctx, c, id, timeout-time.Since(start), done)
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWaitForServerStatus(t *testing.T) {
+	old := serverPollInterval
+	serverPollInterval = time.Millisecond
+	defer func() { serverPollInterval = old }()
+
+	tests := []struct {
+		name     string
+		statuses []string
+		timeout  time.Duration
+		wantErr  any
+	}{
+		{
+			name:     "becomes ready",
+			statuses: []string{"PROVISIONING", "PROVISIONING", "ON"},
+			timeout:  time.Second,
+		},
+		{
+			name:     "provisioning fails",
+			statuses: []string{"PROVISIONING", "FAILED"},
+			timeout:  time.Second,
+			wantErr:  &ServerFailedError{},
+		},
+		{
+			name:     "stalls",
+			statuses: []string{"PROVISIONING"},
+			timeout:  20 * time.Millisecond,
+			wantErr:  &WaitTimeoutError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/servers/server-123" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				// The first poll races the allocation and 404s.
+				if calls == 0 {
+					calls++
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				status := tt.statuses[min(calls-1, len(tt.statuses)-1)]
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{
+					"success": true,
+					"data": map[string]any{
+						"id":                "server-123",
+						"ipAddress":         "192.168.1.100",
+						"devicePowerStatus": status,
+					},
+				})
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, "k123")
+			s, err := waitForServerStatus(context.Background(), c, "server-123", tt.timeout, isReadyPowerStatus)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if powerStatus(s) != "ON" {
+					t.Fatalf("expected status ON, got %q", powerStatus(s))
+				}
+			case *ServerFailedError:
+				if !errors.As(err, &want) {
+					t.Fatalf("expected ServerFailedError, got %v", err)
+				}
+				if want.Status != "FAILED" {
+					t.Fatalf("expected status FAILED, got %q", want.Status)
+				}
+			case *WaitTimeoutError:
+				if !errors.As(err, &want) {
+					t.Fatalf("expected WaitTimeoutError, got %v", err)
+				}
+				if want.LastStatus != "PROVISIONING" {
+					t.Fatalf("expected last status PROVISIONING, got %q", want.LastStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestPowerStateOf(t *testing.T) {
+	tests := map[string]string{
+		"ON":           PowerActionOn,
+		"running":      PowerActionOn,
+		"OFF":          PowerActionOff,
+		"powered_off":  PowerActionOff,
+		"PROVISIONING": "",
+		"":             "",
+	}
+	for status, want := range tests {
+		if got := powerStateOf(status); got != want {
+			t.Errorf("powerStateOf(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestWaitForServerAction(t *testing.T) {
+	oldPoll, oldStart := serverPollInterval, actionStartTimeout
+	serverPollInterval = time.Millisecond
+	actionStartTimeout = 20 * time.Millisecond
+	defer func() { serverPollInterval, actionStartTimeout = oldPoll, oldStart }()
+
+	tests := []struct {
+		name            string
+		statuses        []string
+		wantNotObserved bool
+	}{
+		{
+			// The server reports ON until the reinstall starts.
+			name:     "starts late",
+			statuses: []string{"ON", "ON", "REINSTALLING", "REINSTALLING", "ON"},
+		},
+		{
+			// A reboot can start and finish between two polls.
+			name:            "never seen running",
+			statuses:        []string{"ON"},
+			wantNotObserved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[min(calls, len(tt.statuses)-1)]
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{
+					"success": true,
+					"data": map[string]any{
+						"id":                "server-123",
+						"devicePowerStatus": status,
+					},
+				})
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, "k123")
+			s, err := waitForServerAction(context.Background(), c, "server-123", time.Second, isReadyPowerStatus)
+			if tt.wantNotObserved {
+				var ne *ActionNotObservedError
+				if !errors.As(err, &ne) || powerStatus(s) != "ON" {
+					t.Fatalf("expected the server with an ActionNotObservedError, got %v, %v", s, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if powerStatus(s) != "ON" || calls != len(tt.statuses) {
+				t.Fatalf("expected ON after %d polls, got %q after %d", len(tt.statuses), powerStatus(s), calls)
+			}
+		})
+	}
+}